@@ -2,9 +2,10 @@
 --
 -- SPDX-License-Identifier: GPL-3.0-or-later
 
-DROP TABLE IF EXISTS http_log CASCADE;
+-- Migration files must be additive: do not drop or recreate the existing
+-- tables, since it may contains the access log history.
 
-CREATE TABLE http_log (
+CREATE TABLE IF NOT EXISTS http_log (
   request_date  TIMESTAMP WITH TIME ZONE
 
 , client_ip     VARCHAR
@@ -46,8 +47,6 @@ CREATE TABLE http_log (
 , backend_queue INTEGER
 );
 
-DROP INDEX IF EXISTS http_log_idx;
-
 CREATE INDEX IF NOT EXISTS http_log_idx ON http_log(
   request_date
 , client_ip
@@ -61,8 +60,6 @@ CREATE INDEX IF NOT EXISTS http_log_idx ON http_log(
 , status_code
 );
 
-DROP INDEX IF EXISTS http_log_time_idx;
-
 CREATE INDEX IF NOT EXISTS http_log_time_idx ON http_log(
   time_request
 , time_wait
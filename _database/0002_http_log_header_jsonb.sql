@@ -0,0 +1,21 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Store the captured headers as JSONB.
+-- The previous pipe-joined values are kept in the "_raw" columns and
+-- converted into JSONB by haminer using the configured
+-- capture_request_header.
+
+ALTER TABLE http_log RENAME COLUMN header_request TO header_request_raw;
+ALTER TABLE http_log RENAME COLUMN header_response TO header_response_raw;
+
+ALTER TABLE http_log
+  ADD COLUMN IF NOT EXISTS header_request  JSONB
+, ADD COLUMN IF NOT EXISTS header_response JSONB;
+
+CREATE INDEX IF NOT EXISTS http_log_header_request_idx
+  ON http_log USING GIN (header_request);
+
+CREATE INDEX IF NOT EXISTS http_log_header_response_idx
+  ON http_log USING GIN (header_response);
@@ -0,0 +1,18 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Store the location and autonomous system of client IP, enriched from
+-- the GeoIP database.
+-- The existing rows are set to empty, since they are not enriched.
+
+ALTER TABLE http_log
+  ADD COLUMN IF NOT EXISTS geo_country VARCHAR NOT NULL DEFAULT ''
+, ADD COLUMN IF NOT EXISTS geo_city    VARCHAR NOT NULL DEFAULT ''
+, ADD COLUMN IF NOT EXISTS asn         BIGINT  NOT NULL DEFAULT 0
+, ADD COLUMN IF NOT EXISTS as_org      VARCHAR NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS http_log_geo_idx ON http_log(
+  geo_country
+, asn
+);
@@ -0,0 +1,12 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Store the browser, OS, device type, and bot flag parsed from the
+-- captured request header "user_agent".
+
+ALTER TABLE http_log
+  ADD COLUMN IF NOT EXISTS ua_browser VARCHAR NOT NULL DEFAULT ''
+, ADD COLUMN IF NOT EXISTS ua_os      VARCHAR NOT NULL DEFAULT ''
+, ADD COLUMN IF NOT EXISTS ua_device  VARCHAR NOT NULL DEFAULT ''
+, ADD COLUMN IF NOT EXISTS is_bot     BOOLEAN NOT NULL DEFAULT FALSE;
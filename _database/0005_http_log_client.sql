@@ -0,0 +1,9 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Store the network label and reverse DNS host name of client IP.
+
+ALTER TABLE http_log
+  ADD COLUMN IF NOT EXISTS client_label VARCHAR NOT NULL DEFAULT ''
+, ADD COLUMN IF NOT EXISTS client_host  VARCHAR NOT NULL DEFAULT '';
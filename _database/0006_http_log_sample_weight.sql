@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Store the number of logs represented by each log, set by the sample
+-- rules.
+-- The existing rows are not sampled.
+
+ALTER TABLE http_log
+  ADD COLUMN IF NOT EXISTS sample_weight DOUBLE PRECISION NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Store the flag of request that is not forwarded to any server, with
+-- server "<NOSRV>" or backend "-".
+-- The existing rows are not error, since those logs were rejected.
+
+ALTER TABLE http_log
+  ADD COLUMN IF NOT EXISTS is_error BOOLEAN NOT NULL DEFAULT FALSE;
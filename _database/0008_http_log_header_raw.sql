@@ -0,0 +1,39 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Convert the pipe-joined request headers, stored before migration
+-- "0002_http_log_header_jsonb.sql", into JSONB using the names from the
+-- session setting "haminer.capture_request_header", set by haminer from
+-- the option capture_request_header.
+-- Only the rows where the number of values equal to the number of names
+-- are converted, the rest is kept in column header_request_raw.
+--
+-- The response headers is never captured by haminer, so the column
+-- header_response_raw is dropped if its does not contains any value.
+
+UPDATE http_log SET
+  header_request = (
+    SELECT jsonb_object_agg(h.k, h.v)
+    FROM unnest(
+      string_to_array(current_setting('haminer.capture_request_header', true), ',')
+    , string_to_array(header_request_raw, '|')
+    ) AS h(k, v)
+  )
+, header_request_raw = NULL
+WHERE header_request IS NULL
+  AND header_request_raw <> ''
+  AND coalesce(current_setting('haminer.capture_request_header', true), '') <> ''
+  AND cardinality(string_to_array(header_request_raw, '|'))
+    = cardinality(string_to_array(current_setting('haminer.capture_request_header', true), ','));
+
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM information_schema.columns
+    WHERE table_name = 'http_log' AND column_name = 'header_response_raw')
+  THEN
+    IF NOT EXISTS (SELECT 1 FROM http_log WHERE header_response_raw <> '') THEN
+      ALTER TABLE http_log DROP COLUMN header_response_raw;
+    END IF;
+  END IF;
+END $$;
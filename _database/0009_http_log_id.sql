@@ -0,0 +1,20 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Add unique identifier for each log, used as tiebreaker on the search
+-- cursor since the request_date is not unique.
+--
+-- The column is added without default, so the existing rows are not
+-- rewritten and the table is locked only briefly.
+-- The sequence default is set afterward, so only the new rows get the id.
+-- The existing rows are backfilled using "haminer migrate -backfill-id".
+
+ALTER TABLE http_log
+  ADD COLUMN IF NOT EXISTS id BIGINT;
+
+CREATE SEQUENCE IF NOT EXISTS http_log_id_seq
+  OWNED BY http_log.id;
+
+ALTER TABLE http_log
+  ALTER COLUMN id SET DEFAULT nextval('http_log_id_seq');
@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Index for searching the logs ordered by the newest request, with the
+-- column id as tiebreaker for the same request_date.
+
+CREATE INDEX IF NOT EXISTS http_log_date_id_idx ON http_log(
+  request_date DESC
+, id DESC
+);
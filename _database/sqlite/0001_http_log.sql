@@ -0,0 +1,62 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Migration files for SQLite must be additive: do not drop or recreate
+-- the existing tables, since it may contains the access log history.
+--
+-- The column id is alias of rowid, used as tiebreaker on search.
+-- The request_date is declared as DATETIME so the driver parse it back
+-- into time.
+
+CREATE TABLE IF NOT EXISTS http_log (
+  id            INTEGER PRIMARY KEY
+, request_date  DATETIME NOT NULL
+, client_ip     TEXT NOT NULL DEFAULT ''
+, client_label  TEXT NOT NULL DEFAULT ''
+, client_host   TEXT NOT NULL DEFAULT ''
+, geo_country   TEXT NOT NULL DEFAULT ''
+, geo_city      TEXT NOT NULL DEFAULT ''
+, asn           INTEGER NOT NULL DEFAULT 0
+, as_org        TEXT NOT NULL DEFAULT ''
+, ua_browser    TEXT NOT NULL DEFAULT ''
+, ua_os         TEXT NOT NULL DEFAULT ''
+, ua_device     TEXT NOT NULL DEFAULT ''
+, is_bot        BOOLEAN NOT NULL DEFAULT FALSE
+, is_error      BOOLEAN NOT NULL DEFAULT FALSE
+, frontend_name TEXT
+, backend_name  TEXT
+, server_name   TEXT
+, http_proto    TEXT
+, http_method   TEXT
+, http_url      TEXT
+, http_query    TEXT
+, header_request  TEXT
+, header_response TEXT
+, cookie_request    TEXT
+, cookie_response   TEXT
+, termination_state TEXT
+, bytes_read    INTEGER
+, sample_weight REAL NOT NULL DEFAULT 1
+, status_code   INTEGER
+, client_port   INTEGER
+, time_request  INTEGER
+, time_wait     INTEGER
+, time_connect  INTEGER
+, time_response INTEGER
+, time_all      INTEGER
+, conn_active   INTEGER
+, conn_frontend INTEGER
+, conn_backend  INTEGER
+, conn_server   INTEGER
+, retries       INTEGER
+, server_queue  INTEGER
+, backend_queue INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS http_log_date_idx ON http_log(request_date);
+
+CREATE INDEX IF NOT EXISTS http_log_backend_idx ON http_log(
+  backend_name
+, request_date
+);
@@ -0,0 +1,14 @@
+-- SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+--
+-- SPDX-License-Identifier: GPL-3.0-or-later
+
+-- Index for searching the logs ordered by the newest request, with the
+-- column id as tiebreaker for the same request_date.
+-- It replace the index http_log_date_idx.
+
+CREATE INDEX IF NOT EXISTS http_log_date_id_idx ON http_log(
+  request_date DESC
+, id DESC
+);
+
+DROP INDEX IF EXISTS http_log_date_idx;
@@ -2,7 +2,46 @@
 //
 // SPDX-License-Identifier: GPL-3.0-or-later
 
+interface DashboardCount {
+  key: string;
+  count: number;
+}
+
+interface DashboardStats {
+  time: string;
+  rps_backend: { [key: string]: number };
+  status_class: { [key: string]: number };
+  term_state: { [key: string]: number };
+  top_url: DashboardCount[];
+  top_client_ip: DashboardCount[];
+  time_all: { [key: string]: number };
+  window: number;
+  requests: number;
+}
+
 class Haminer {
+  // apiDashboard render the statistics from "/api/dashboard" into the
+  // elements with id prefix.
+  apiDashboard(prefix: string) {
+    const evtSource = new EventSource("/api/dashboard");
+
+    evtSource.onmessage = (event) => {
+      const stats: DashboardStats = JSON.parse(event.data);
+
+      const elSummary = document.getElementById(`${prefix}-summary`);
+      if (elSummary) {
+        elSummary.textContent = `${stats.time}: ${stats.requests} requests in the last ${stats.window} seconds`;
+      }
+
+      this.renderMap(`${prefix}-rps-backend`, stats.rps_backend, 2);
+      this.renderMap(`${prefix}-status-class`, stats.status_class, 0);
+      this.renderMap(`${prefix}-time-all`, stats.time_all, 0);
+      this.renderMap(`${prefix}-term-state`, stats.term_state, 0);
+      this.renderList(`${prefix}-top-url`, stats.top_url);
+      this.renderList(`${prefix}-top-client-ip`, stats.top_client_ip);
+    };
+  }
+
   apiLogTail(id: string) {
     var comp = document.getElementById(id);
 
@@ -11,12 +50,50 @@ class Haminer {
     evtSource.onmessage = (event) => {
       const elLog = document.createElement("div");
 
-      console.log(`${event.data}`);
-
       elLog.textContent = event.data;
 
       comp.prepend(elLog);
+
+      while (comp.childElementCount > 1000) {
+        comp.lastElementChild.remove();
+      }
     };
+
+    evtSource.addEventListener("missed", (event: MessageEvent) => {
+      const elLog = document.createElement("div");
+      elLog.textContent = `... missed ${event.data} lines`;
+      comp.prepend(elLog);
+    });
+  }
+
+  renderMap(id: string, data: { [key: string]: number }, digits: number) {
+    const list: DashboardCount[] = [];
+    for (const key in data) {
+      list.push({ key: key, count: data[key] });
+    }
+    this.renderList(id, list, digits);
+  }
+
+  renderList(id: string, list: DashboardCount[], digits: number = 0) {
+    const elTable = document.getElementById(id);
+    if (!elTable) {
+      return;
+    }
+    elTable.replaceChildren();
+    for (const item of list || []) {
+      const elRow = document.createElement("tr");
+
+      const elKey = document.createElement("td");
+      elKey.textContent = item.key;
+      elRow.appendChild(elKey);
+
+      const elCount = document.createElement("td");
+      elCount.className = "num";
+      elCount.textContent = item.count.toFixed(digits);
+      elRow.appendChild(elCount);
+
+      elTable.appendChild(elRow);
+    }
   }
 }
 
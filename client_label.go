@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// clientLabel the name of network.
+type clientLabel struct {
+	name   string
+	prefix netip.Prefix
+}
+
+// clientLabeler label the client IP with the name of network that
+// contains it.
+type clientLabeler struct {
+	// labels sorted by the length of network prefix, from the longest
+	// one, so the most specific network is matched first.
+	labels []clientLabel
+}
+
+// newClientLabeler parse list of label in the format
+// "name: CIDR [, CIDR ...]".
+// It will return nil if rules is empty.
+func newClientLabeler(rules []string) (labeler *clientLabeler, err error) {
+	var (
+		rule   string
+		name   string
+		cidrs  string
+		cidr   string
+		prefix netip.Prefix
+		ok     bool
+	)
+	for _, rule = range rules {
+		name, cidrs, ok = strings.Cut(rule, `:`)
+		name = strings.TrimSpace(name)
+		if !ok || len(name) == 0 {
+			return nil, fmt.Errorf(`invalid label %q`, rule)
+		}
+		for _, cidr = range strings.Split(cidrs, `,`) {
+			cidr = strings.TrimSpace(cidr)
+			if len(cidr) == 0 {
+				continue
+			}
+			prefix, err = netip.ParsePrefix(cidr)
+			if err != nil {
+				return nil, fmt.Errorf(`label %q: %w`, name, err)
+			}
+			if labeler == nil {
+				labeler = &clientLabeler{}
+			}
+			labeler.labels = append(labeler.labels, clientLabel{
+				name:   name,
+				prefix: prefix.Masked(),
+			})
+		}
+	}
+	if labeler == nil {
+		return nil, nil
+	}
+
+	slices.SortStableFunc(labeler.labels, func(a, b clientLabel) int {
+		return b.prefix.Bits() - a.prefix.Bits()
+	})
+	return labeler, nil
+}
+
+// label return the name of network that contains the ip, or empty string
+// if no network match.
+func (labeler *clientLabeler) label(ip string) string {
+	if labeler == nil {
+		return ``
+	}
+
+	var addr, err = netip.ParseAddr(ip)
+	if err != nil {
+		return ``
+	}
+	addr = addr.Unmap()
+
+	for _, label := range labeler.labels {
+		if label.prefix.Contains(addr) {
+			return label.name
+		}
+	}
+	return ``
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestClientLabeler_label(t *testing.T) {
+	var labeler, err = newClientLabeler([]string{
+		`internal: 10.0.0.0/8, 192.168.0.0/16`,
+		`k8s-nodes: 10.2.0.0/16`,
+		`office: 2001:db8::/32`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases = []struct {
+		ip  string
+		exp string
+	}{{
+		ip:  `10.1.2.3`,
+		exp: `internal`,
+	}, {
+		// The most specific network is used.
+		ip:  `10.2.0.1`,
+		exp: `k8s-nodes`,
+	}, {
+		ip:  `192.168.1.1`,
+		exp: `internal`,
+	}, {
+		ip:  `2001:db8::1`,
+		exp: `office`,
+	}, {
+		ip:  `8.8.8.8`,
+		exp: ``,
+	}, {
+		ip:  `-`,
+		exp: ``,
+	}}
+
+	for _, c := range cases {
+		test.Assert(t, c.ip, c.exp, labeler.label(c.ip))
+	}
+}
+
+func TestNewClientLabeler(t *testing.T) {
+	var cases = []struct {
+		rule   string
+		expErr string
+	}{{
+		rule:   `10.0.0.0/8`,
+		expErr: `invalid label "10.0.0.0/8"`,
+	}, {
+		rule:   `office: 10.0.0.0`,
+		expErr: `label "office": netip.ParsePrefix("10.0.0.0"): no '/'`,
+	}}
+
+	for _, c := range cases {
+		var _, err = newClientLabeler([]string{c.rule})
+		test.Assert(t, c.rule, c.expErr, err.Error())
+	}
+}
@@ -5,8 +5,11 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
@@ -20,46 +23,325 @@ const (
 	defConfig    = "/etc/haminer.conf"
 )
 
+// List of commands.
+const (
+	cmdCheckConfig    = `check-config`
+	cmdMigrate        = `migrate`
+	cmdParse          = `parse`
+	cmdReplay         = `replay`
+	cmdRun            = `run`
+	cmdTestForwarders = `test-forwarders`
+)
+
+const usage = `Usage: haminer [-config file] [-dev] [command] [options]
+
+Commands:
+
+  run
+	Receive the logs from HAProxy and forward them.
+	This is the default command.
+
+  check-config
+	Validate the configuration and print the effective configuration,
+	with passwords and tokens redacted.
+
+  parse [file ...]
+	Parse each line of HAProxy log from files or standard input, and
+	print the result in JSON.
+
+  replay [options] <file|-> ...
+	Forward the logs from files or standard input to the forwarders.
+	Run "haminer replay -h" for list of options.
+
+  test-forwarders
+	Send one synthetic log, with backend "haminer-test", to each
+	forwarder.
+	The database schema is not created nor migrated.
+
+  migrate [-dry-run] [-backfill-id]
+	Run the database migration on the postgresql and sqlite
+	forwarders and exit.
+	With option "-dry-run", print the pending migration without
+	applying them.
+	With option "-backfill-id", set the id of the logs that stored
+	before the column id added, in batch, after the migration.
+	The deprecated options "-migrate-only" and "-dry-run", before the
+	command, are equal to "migrate" and "migrate -dry-run".
+
+Options:
+`
+
 func main() {
 	var (
-		chSignal = make(chan os.Signal, 1)
-		cfg      = haminer.NewConfig()
+		cfg = haminer.NewConfig()
 
 		err        error
 		flagConfig string
+
+		// flagMigrateOnly and flagDryRun are deprecated, replaced
+		// by command "migrate".
+		flagMigrateOnly bool
+		flagDryRun      bool
 	)
 
 	log.SetPrefix(defLogPrefix)
 
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
 	flag.StringVar(&flagConfig, `config`, defConfig, `Path to configuration`)
 	flag.BoolVar(&cfg.IsDevelopment, `dev`, false, `Enable development mode`)
+	flag.BoolVar(&flagMigrateOnly, `migrate-only`, false,
+		`Deprecated, use command "migrate"`)
+	flag.BoolVar(&flagDryRun, `dry-run`, false,
+		`Deprecated, use command "migrate -dry-run"`)
 
 	flag.Parse()
 
+	var (
+		cmd  = flag.Arg(0)
+		args []string
+	)
+	if len(cmd) == 0 {
+		cmd = cmdRun
+	} else {
+		args = flag.Args()[1:]
+	}
+
+	if flagMigrateOnly || flagDryRun {
+		log.Printf(`option -migrate-only and -dry-run are deprecated, use command %q`,
+			cmdMigrate)
+		if len(flag.Arg(0)) != 0 && cmd != cmdMigrate {
+			log.Printf(`option -migrate-only and -dry-run cannot be used with command %q`,
+				cmd)
+			os.Exit(2)
+		}
+		cmd = cmdMigrate
+		if flagDryRun {
+			args = append([]string{`-dry-run`}, args...)
+		}
+	}
+
 	err = cfg.Load(flagConfig)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("Starting Haminer with config: %+v\n", cfg)
+	switch cmd {
+	case cmdRun:
+		err = run(cfg, flagConfig)
+	case cmdCheckConfig:
+		err = checkConfig(cfg)
+	case cmdParse:
+		err = parse(cfg, args)
+	case cmdReplay:
+		err = replay(cfg, args)
+	case cmdTestForwarders:
+		err = haminer.CheckForwarders(cfg, os.Stdout)
+	case cmdMigrate:
+		err = migrate(cfg, args)
+	default:
+		log.Printf(`unknown command %q`, cmd)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
 
-	var h *haminer.Haminer
+// run receive the logs from HAProxy and forward them until the program
+// receive signal to stop.
+// On SIGHUP, the configuration is loaded from file cfgPath and applied
+// without restarting the program.
+func run(cfg *haminer.Config, cfgPath string) (err error) {
+	var (
+		chSignal = make(chan os.Signal, 1)
+
+		h *haminer.Haminer
+	)
+
+	log.Printf(`starting haminer %s`, haminer.Version)
 
 	h, err = haminer.NewHaminer(cfg)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	signal.Notify(chSignal, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 
 	go func() {
-		err = h.Start()
-		if err != nil {
-			log.Fatal(err)
+		var errStart = h.Start()
+		if errStart != nil {
+			log.Fatal(errStart)
 		}
 	}()
 
-	<-chSignal
+	var sig os.Signal
+	for sig = range chSignal {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		reloadConfig(h, cfg.IsDevelopment, cfgPath)
+	}
+
 	h.Stop()
 	signal.Stop(chSignal)
+
+	return nil
+}
+
+// reloadConfig load the configuration from cfgPath and apply it to h.
+// If the configuration is invalid, the current configuration is kept.
+func reloadConfig(h *haminer.Haminer, isDev bool, cfgPath string) {
+	var (
+		newCfg = haminer.NewConfig()
+		err    error
+	)
+
+	log.Printf(`reloading configuration %s`, cfgPath)
+
+	newCfg.IsDevelopment = isDev
+
+	err = newCfg.Load(cfgPath)
+	if err != nil {
+		log.Printf(`reload: %s`, err)
+		return
+	}
+
+	err = h.Reload(newCfg)
+	if err != nil {
+		log.Printf(`reload: %s`, err)
+	}
+}
+
+// checkConfig print the effective configuration with secrets redacted.
+// The configuration has been validated when its loaded.
+func checkConfig(cfg *haminer.Config) (err error) {
+	var out []byte
+
+	out, err = cfg.MarshalRedacted()
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(out))
+
+	return nil
+}
+
+// parse print each line of HAProxy logs from files, or standard input if
+// no files, as JSON.
+// The line that cannot be parsed is reported to standard error.
+func parse(cfg *haminer.Config, files []string) (err error) {
+	if len(files) == 0 {
+		return parseReader(cfg, `-`, os.Stdin)
+	}
+
+	var (
+		file string
+		f    *os.File
+	)
+	for _, file = range files {
+		f, err = os.Open(file)
+		if err != nil {
+			return err
+		}
+
+		err = parseReader(cfg, file, f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseReader(cfg *haminer.Config, name string, r io.Reader) (err error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		enc     = json.NewEncoder(os.Stdout)
+
+		halog  *haminer.HTTPLog
+		lineno int
+	)
+
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+
+	for scanner.Scan() {
+		lineno++
+
+		halog = haminer.ParseUDPPacket(scanner.Bytes(), cfg.RequestHeaders)
+		if halog == nil {
+			log.Printf(`%s:%d: invalid log`, name, lineno)
+			continue
+		}
+
+		err = enc.Encode(halog)
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// replay run the "replay" command, forward the logs from files to the
+// configured forwarders.
+func replay(cfg *haminer.Config, args []string) (err error) {
+	var (
+		flagReplay = flag.NewFlagSet(cmdReplay, flag.ExitOnError)
+		opts       = haminer.ReplayOptions{
+			Out: os.Stdout,
+		}
+	)
+
+	flagReplay.Usage = func() {
+		fmt.Fprintf(flagReplay.Output(),
+			"Usage: haminer [-config file] replay [options] <file|-> ...\n\n")
+		flagReplay.PrintDefaults()
+	}
+	flagReplay.IntVar(&opts.Rate, `rate`, 0,
+		`Maximum number of logs forwarded per second, 0 means unlimited`)
+	flagReplay.IntVar(&opts.BatchSize, `batch`, 1000,
+		`Number of logs forwarded at once`)
+	flagReplay.Int64Var(&opts.Offset, `offset`, 0,
+		`Number of lines to be skipped, to resume the previous replay`)
+	flagReplay.StringVar(&opts.StateFile, `state`, ``,
+		`Path to file to store and resume the offset`)
+
+	err = flagReplay.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	opts.Files = flagReplay.Args()
+	if len(opts.Files) == 0 {
+		flagReplay.Usage()
+		os.Exit(2)
+	}
+
+	return haminer.Replay(cfg, opts)
+}
+
+// migrate run the database migration.
+func migrate(cfg *haminer.Config, args []string) (err error) {
+	var (
+		flagMigrate = flag.NewFlagSet(cmdMigrate, flag.ExitOnError)
+
+		flagDryRun     bool
+		flagBackfillID bool
+	)
+
+	flagMigrate.BoolVar(&flagDryRun, `dry-run`, false,
+		`Print the pending database migration without applying them`)
+	flagMigrate.BoolVar(&flagBackfillID, `backfill-id`, false,
+		`Set the id of the existing logs after the migration`)
+
+	err = flagMigrate.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	return haminer.Migrate(cfg, flagDryRun, flagBackfillID, os.Stdout)
 }
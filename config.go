@@ -6,6 +6,8 @@ package haminer
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -20,10 +22,28 @@ const (
 	defForwardInterval = 15 * time.Second
 )
 
+// redactedValue the value that replace the secret in the output of
+// [Config.MarshalRedacted].
+const redactedValue = `xxxxx`
+
 // Config define options to create and run Haminer instance.
 type Config struct {
 	Forwarders map[string]*ConfigForwarder `ini:"forwarder"`
 
+	// PII list of named policy to protect the personally identifiable
+	// information.
+	// The policy "default" applied to the live tail, dashboard, and
+	// forwarders that does not set option "pii".
+	PII map[string]*ConfigPII `ini:"pii"`
+
+	piiPolicies map[string]*piiPolicy
+
+	// Sample list of named rules to forward only the sample of logs
+	// that match the backend and URL.
+	Sample map[string]*ConfigSample `ini:"sample"`
+
+	sampler *sampler
+
 	// Listen is the address where Haminer will bind and receiving
 	// log from HAProxy.
 	Listen string `ini:"haminer::listen"`
@@ -33,18 +53,159 @@ type Config struct {
 	// WuiAddress the address to serve for web user interface.
 	WuiAddress string `ini:"haminer::wui_address"`
 
+	// WuiTLSCert and WuiTLSKey the path to the TLS certificate and
+	// private key to serve the web user interface over HTTPS.
+	WuiTLSCert string `ini:"haminer::wui_tls_cert"`
+	WuiTLSKey  string `ini:"haminer::wui_tls_key"`
+
+	// WuiBasicAuth list of "user:password" that can access the web
+	// user interface using basic authentication.
+	WuiBasicAuth []string `ini:"haminer::wui_basic_auth"`
+
+	// WuiToken list of static tokens that can access the web user
+	// interface using header "Authorization: Bearer <token>".
+	WuiToken []string `ini:"haminer::wui_token"`
+
+	// WuiAllowCIDR list of source network, in CIDR notation, that
+	// allowed to access the web user interface.
+	// If its empty, all sources are allowed.
+	WuiAllowCIDR []string `ini:"haminer::wui_allow_cidr"`
+
+	// WuiShowCookie if true, the request and response cookies that
+	// are not masked by PII policy are included in the live tail and
+	// search API.
+	WuiShowCookie bool `ini:"haminer::wui_show_cookie"`
+
+	// wuiUsers map of user name and password from WuiBasicAuth.
+	wuiUsers map[string]string
+
+	wuiAllowNets []*net.IPNet
+
 	// AcceptBackend list of backend to be filtered.
 	AcceptBackend []string `ini:"haminer::accept_backend"`
 
+	// Filter list of expressions to accept the logs.
+	// If its not empty, only the log that match one of the expression
+	// is accepted.
+	Filter []string `ini:"haminer::filter"`
+
+	// Drop list of expressions to reject the logs.
+	// The log that match one of the expression is rejected.
+	Drop []string `ini:"haminer::drop"`
+
+	filters []filterExpr
+	drops   []filterExpr
+
+	// SampleKeep list of expressions to match the logs that are always
+	// forwarded by the sampler.
+	// Default to the error log, the log with status code 500 or above,
+	// and the log that does not terminated normally.
+	SampleKeep []string `ini:"haminer::sample_keep"`
+
+	// IncludeError if true, the log of request that is not forwarded to
+	// any server, with server "<NOSRV>" or backend "-", is accepted and
+	// marked as error.
+	// By default those logs are rejected.
+	IncludeError bool `ini:"haminer::include_error"`
+
 	// List of request headers to be parsed and mapped as tags in halog
 	// output.
 	RequestHeaders []string `ini:"haminer::capture_request_header"`
 
-	HTTPURL []string `ini:"preprocess:tag:http_url"`
+	// GeoIPCity and GeoIPASN the path to MaxMind DB files, GeoIP2 or
+	// GeoLite2, to enrich the log with the country, city, and autonomous
+	// system of client IP.
+	// The files are re-opened when they changes on disk.
+	GeoIPCity string `ini:"haminer::geoip_city"`
+	GeoIPASN  string `ini:"haminer::geoip_asn"`
+
+	geoip *geoIP
+
+	// ClientLabel list of named networks, in the format
+	// "name: CIDR [, CIDR ...]", to label the client IP.
+	// If the client IP is in more than one networks, the most specific
+	// one is used.
+	ClientLabel []string `ini:"enrich:client_ip:label"`
+
+	clientLabeler *clientLabeler
+
+	// ReverseDNS if true, the host name of client IP is resolved in the
+	// background and cached.
+	ReverseDNS bool `ini:"enrich:client_ip:reverse_dns"`
+
+	// ReverseDNSRate the maximum number of reverse DNS lookups per
+	// second, default to 10.
+	ReverseDNSRate int `ini:"enrich:client_ip:reverse_dns_rate"`
+
+	// ReverseDNSCacheSize the maximum number of IP address cached,
+	// default to 10000.
+	ReverseDNSCacheSize int `ini:"enrich:client_ip:reverse_dns_cache_size"`
+
+	// ReverseDNSTTL the duration of host name cached, default to 1h.
+	ReverseDNSTTL time.Duration `ini:"enrich:client_ip:reverse_dns_ttl"`
+
+	// Resolver the custom resolver for reverse DNS.
+	// If its nil, the [net.DefaultResolver] is used.
+	// It must be set before calling [Config.Load].
+	Resolver Resolver
+
+	rdns *reverseDNS
+
+	// List of pre-processing rules for each tag, in the format
+	// "regex => replacement".
+	// The PreprocessHeader rule is prefixed with the request header
+	// name, in the format "name: regex => replacement".
+	HTTPURL             []string `ini:"preprocess:tag:http_url"`
+	PreprocessHTTPQuery []string `ini:"preprocess:tag:http_query"`
+	PreprocessClientIP  []string `ini:"preprocess:tag:client_ip"`
+	PreprocessServer    []string `ini:"preprocess:tag:server"`
+	PreprocessBackend   []string `ini:"preprocess:tag:backend"`
+	PreprocessHeader    []string `ini:"preprocess:tag:header"`
+
+	// QueryNormalize if true, the HTTP query is normalized using the
+	// default redact list.
+	// The HTTP query is also normalized if one of QueryAllow, QueryDeny,
+	// or QueryRedact is set.
+	QueryNormalize bool `ini:"preprocess:query:normalize"`
+
+	// QueryAllow list of query parameters to be kept.
+	// If its not empty, other parameters are removed.
+	QueryAllow []string `ini:"preprocess:query:allow"`
+
+	// QueryDeny list of query parameters to be removed.
+	QueryDeny []string `ini:"preprocess:query:deny"`
+
+	// QueryRedact list of query parameters whose value replaced with
+	// "xxxxx".
+	// Default to "password", "sig", and "token".
+	QueryRedact []string `ini:"preprocess:query:redact"`
+
+	// queryp normalize the HTTP query, nil if normalization is not
+	// enabled.
+	queryp *queryProcessor
+
+	// URLTemplate if true, the tag http_url is converted into template,
+	// by replacing the path segment that looks like an identifier with
+	// placeholder, for example "/users/1000" become "/users/:id".
+	URLTemplate bool `ini:"preprocess:url:template"`
+
+	// URLMaxTemplates the maximum number of templates learned for each
+	// backend, default to 500.
+	URLMaxTemplates int `ini:"preprocess:url:max_templates"`
+
+	// URLMinCount the number of times the template must be seen before
+	// its learned, default to 3.
+	URLMinCount int `ini:"preprocess:url:min_count"`
+
+	// urlt convert the http_url tag into template.
+	urlt *urlTemplater
 
 	// retags contains list of pre-processing rules for tag.
 	retags []*tagPreprocessor
 
+	// hasHeaderRetag true if retags contains rule for request header.
+	hasHeaderRetag bool
+
 	// ForwardInterval define an interval where logs will be forwarded.
 	ForwardInterval time.Duration `ini:"haminer::forward_interval"`
 
@@ -65,6 +226,10 @@ func NewConfig() (cfg *Config) {
 }
 
 // Load configuration from file defined by `path`.
+//
+// The options in the file can be overridden by environment variables with
+// prefix "HAMINER_", and the "${NAME}" in the option value is replaced
+// with the value of environment variable NAME.
 func (cfg *Config) Load(path string) (err error) {
 	if len(path) == 0 {
 		return
@@ -83,11 +248,18 @@ func (cfg *Config) Load(path string) (err error) {
 		return fmt.Errorf(`%s: %w`, logp, err)
 	}
 
+	applyEnv(in)
+
 	err = in.Unmarshal(cfg)
 	if err != nil {
 		return fmt.Errorf(`%s: %w`, logp, err)
 	}
 
+	err = expandEnvStruct(cfg)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
 	if len(cfg.Listen) != 0 {
 		cfg.SetListen(cfg.Listen)
 	}
@@ -97,7 +269,62 @@ func (cfg *Config) Load(path string) (err error) {
 		return fmt.Errorf(`%s: %w`, logp, err)
 	}
 
+	if cfg.QueryNormalize || len(cfg.QueryAllow) != 0 ||
+		len(cfg.QueryDeny) != 0 || len(cfg.QueryRedact) != 0 {
+		cfg.queryp = newQueryProcessor(cfg.QueryAllow, cfg.QueryDeny, cfg.QueryRedact)
+	}
+
+	if cfg.URLTemplate {
+		cfg.urlt = newURLTemplater(cfg.URLMaxTemplates, cfg.URLMinCount)
+	}
+
+	err = cfg.initWui()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = cfg.initPII()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	cfg.geoip.close()
+	cfg.geoip, err = newGeoIP(cfg.GeoIPCity, cfg.GeoIPASN)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	cfg.filters, err = compileFilterExprs(cfg.Filter)
+	if err != nil {
+		return fmt.Errorf(`%s: filter: %w`, logp, err)
+	}
+	cfg.drops, err = compileFilterExprs(cfg.Drop)
+	if err != nil {
+		return fmt.Errorf(`%s: drop: %w`, logp, err)
+	}
+
+	cfg.sampler, err = newSampler(cfg.Sample, cfg.SampleKeep)
+	if err != nil {
+		return fmt.Errorf(`%s: sample: %w`, logp, err)
+	}
+
+	cfg.clientLabeler, err = newClientLabeler(cfg.ClientLabel)
+	if err != nil {
+		return fmt.Errorf(`%s: client_ip: %w`, logp, err)
+	}
+
+	cfg.rdns.close()
+	cfg.rdns = nil
+	if cfg.ReverseDNS {
+		cfg.rdns = newReverseDNS(cfg.Resolver, cfg.ReverseDNSRate,
+			cfg.ReverseDNSCacheSize, cfg.ReverseDNSTTL)
+	}
+
 	for fwName, fwCfg = range cfg.Forwarders {
+		err = expandEnvStruct(fwCfg)
+		if err != nil {
+			return fmt.Errorf(`%s: %s: %w`, logp, fwName, err)
+		}
 		err = fwCfg.init(fwName)
 		if err != nil {
 			return fmt.Errorf(`%s: %s: %w`, logp, fwName, err)
@@ -131,29 +358,261 @@ func (cfg *Config) SetListen(v string) {
 
 func (cfg *Config) parsePreprocessTag() (err error) {
 	var (
-		logp = `parsePreprocessTag`
+		logp  = `parsePreprocessTag`
+		rules = []struct {
+			name string
+			vals []string
+		}{
+			{tagNameHTTPURL, cfg.HTTPURL},
+			{tagNameHTTPQuery, cfg.PreprocessHTTPQuery},
+			{tagNameClientIP, cfg.PreprocessClientIP},
+			{tagNameServer, cfg.PreprocessServer},
+			{tagNameBackend, cfg.PreprocessBackend},
+			{tagNameHeader, cfg.PreprocessHeader},
+		}
 
-		retag   *tagPreprocessor
-		httpURL string
-		vals    []string
+		retag *tagPreprocessor
+		name  string
+		rule  string
+		vals  []string
 	)
 
-	for _, httpURL = range cfg.HTTPURL {
-		vals = strings.Split(httpURL, `=>`)
-		if len(vals) != 2 {
-			continue
+	cfg.retags = nil
+	cfg.hasHeaderRetag = false
+
+	for _, r := range rules {
+		for _, rule = range r.vals {
+			name = r.name
+			if name == tagNameHeader {
+				var hdrName string
+
+				hdrName, rule, _ = strings.Cut(rule, `:`)
+				hdrName = strings.TrimSpace(hdrName)
+				if len(hdrName) == 0 {
+					continue
+				}
+				name = tagNameHeaderPrefix + hdrName
+			}
+
+			vals = strings.Split(rule, `=>`)
+			if len(vals) != 2 {
+				continue
+			}
+
+			retag, err = newTagPreprocessor(name, vals[0], vals[1])
+			if err != nil {
+				return fmt.Errorf(`%s: %w`, logp, err)
+			}
+			if retag == nil {
+				continue
+			}
+
+			cfg.retags = append(cfg.retags, retag)
+			if r.name == tagNameHeader {
+				cfg.hasHeaderRetag = true
+			}
 		}
+	}
+
+	return nil
+}
 
-		retag, err = newTagPreprocessor(`http_url`, vals[0], vals[1])
+// preprocessTag return the value of tag name after applying all of the
+// pre-processing rules for the tag.
+func (cfg *Config) preprocessTag(name, value string) string {
+	for _, retag := range cfg.retags {
+		value = retag.preprocess(name, value)
+	}
+	return value
+}
+
+// initPII validate and create the PII policies, and check the policy
+// used by each forwarder.
+func (cfg *Config) initPII() (err error) {
+	var (
+		logp = `initPII`
+
+		piiCfg *ConfigPII
+		pii    *piiPolicy
+		name   string
+	)
+
+	cfg.piiPolicies = nil
+	for name, piiCfg = range cfg.PII {
+		err = expandEnvStruct(piiCfg)
+		if err != nil {
+			return fmt.Errorf(`%s: %s: %w`, logp, name, err)
+		}
+		pii, err = newPIIPolicy(piiCfg)
 		if err != nil {
-			return fmt.Errorf(`%s: %w`, logp, err)
+			return fmt.Errorf(`%s: %s: %w`, logp, name, err)
 		}
-		if retag == nil {
+		if pii == nil {
 			continue
 		}
+		if cfg.piiPolicies == nil {
+			cfg.piiPolicies = make(map[string]*piiPolicy)
+		}
+		cfg.piiPolicies[name] = pii
+	}
 
-		cfg.retags = append(cfg.retags, retag)
+	var fwCfg *ConfigForwarder
+	for name, fwCfg = range cfg.Forwarders {
+		if len(fwCfg.PII) == 0 || fwCfg.PII == piiNone {
+			continue
+		}
+		_, ok := cfg.PII[fwCfg.PII]
+		if !ok {
+			return fmt.Errorf(`%s: forwarder %s: unknown pii %q`, logp,
+				name, fwCfg.PII)
+		}
+	}
+	return nil
+}
+
+// piiPolicy return the PII policy for the forwarder fwName.
+// If fwName is empty, it will return the default policy.
+// It will return nil if no policy applied.
+func (cfg *Config) piiPolicy(fwName string) *piiPolicy {
+	var name = piiDefault
+
+	var fwCfg = cfg.Forwarders[fwName]
+	if fwCfg != nil && len(fwCfg.PII) != 0 {
+		name = fwCfg.PII
+	}
+	return cfg.piiPolicies[name]
+}
+
+// initWui parse and validate the web user interface options.
+func (cfg *Config) initWui() (err error) {
+	var logp = `initWui`
+
+	if (len(cfg.WuiTLSCert) == 0) != (len(cfg.WuiTLSKey) == 0) {
+		return fmt.Errorf(`%s: wui_tls_cert and wui_tls_key must be set together`,
+			logp)
+	}
+
+	var (
+		v    string
+		user string
+		pass string
+		x    int
+		ok   bool
+	)
+
+	cfg.wuiUsers = nil
+	for x, v = range cfg.WuiBasicAuth {
+		// The value is not reported, since it may contains the
+		// password.
+		user, pass, ok = strings.Cut(v, `:`)
+		if !ok {
+			return fmt.Errorf(`%s: invalid wui_basic_auth #%d`, logp, x+1)
+		}
+		if len(user) == 0 || len(pass) == 0 {
+			return fmt.Errorf(`%s: invalid wui_basic_auth #%d for user %q`,
+				logp, x+1, user)
+		}
+		if cfg.wuiUsers == nil {
+			cfg.wuiUsers = make(map[string]string)
+		}
+		cfg.wuiUsers[user] = pass
+	}
+
+	var ipnet *net.IPNet
+
+	cfg.wuiAllowNets = nil
+	for _, v = range cfg.WuiAllowCIDR {
+		_, ipnet, err = net.ParseCIDR(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf(`%s: invalid wui_allow_cidr %q`, logp, v)
+		}
+		cfg.wuiAllowNets = append(cfg.wuiAllowNets, ipnet)
 	}
 
 	return nil
 }
+
+// MarshalRedacted encode the effective configuration into INI format,
+// with all passwords and tokens replaced with "xxxxx".
+func (cfg *Config) MarshalRedacted() (out []byte, err error) {
+	var (
+		redacted = *cfg
+
+		fwName string
+		fwCfg  *ConfigForwarder
+		v      string
+		x      int
+	)
+
+	redacted.Forwarders = make(map[string]*ConfigForwarder,
+		len(cfg.Forwarders))
+	for fwName, fwCfg = range cfg.Forwarders {
+		var fwRedacted = *fwCfg
+
+		fwRedacted.URL = redactURL(fwCfg.URL)
+		fwRedacted.ExecURL = redactURL(fwCfg.ExecURL)
+		if len(fwCfg.Pass) != 0 {
+			fwRedacted.Pass = redactedValue
+		}
+		if len(fwCfg.Token) != 0 {
+			fwRedacted.Token = redactedValue
+		}
+		redacted.Forwarders[fwName] = &fwRedacted
+	}
+
+	redacted.PII = make(map[string]*ConfigPII, len(cfg.PII))
+	for fwName, piiCfg := range cfg.PII {
+		var piiRedacted = *piiCfg
+		if len(piiCfg.HMACKey) != 0 {
+			piiRedacted.HMACKey = redactedValue
+		}
+		redacted.PII[fwName] = &piiRedacted
+	}
+
+	redacted.WuiBasicAuth = make([]string, len(cfg.WuiBasicAuth))
+	for x, v = range cfg.WuiBasicAuth {
+		v, _, _ = strings.Cut(v, `:`)
+		redacted.WuiBasicAuth[x] = v + `:` + redactedValue
+	}
+
+	redacted.WuiToken = make([]string, len(cfg.WuiToken))
+	for x = range cfg.WuiToken {
+		redacted.WuiToken[x] = redactedValue
+	}
+
+	out, err = ini.Marshal(&redacted)
+	if err != nil {
+		return nil, fmt.Errorf(`MarshalRedacted: %w`, err)
+	}
+	return out, nil
+}
+
+// redactURL replace the password in the URL, or in the key "password" of
+// PostgreSQL connection string, with "xxxxx".
+func redactURL(rawURL string) string {
+	if len(rawURL) == 0 {
+		return rawURL
+	}
+
+	var u, err = url.Parse(rawURL)
+	if err == nil && len(u.Scheme) != 0 {
+		var q = u.Query()
+		if q.Has(`password`) {
+			q.Set(`password`, redactedValue)
+			u.RawQuery = q.Encode()
+		}
+		var pass, ok = u.User.Password()
+		if ok && len(pass) != 0 {
+			u.User = url.UserPassword(u.User.Username(), redactedValue)
+		}
+		return u.String()
+	}
+
+	var fields = strings.Fields(rawURL)
+	for x, field := range fields {
+		if strings.HasPrefix(field, `password=`) {
+			fields[x] = `password=` + redactedValue
+		}
+	}
+	return strings.Join(fields, ` `)
+}
@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/ini"
+)
+
+// envPrefix the prefix of environment variable to override the
+// configuration.
+const envPrefix = `HAMINER_`
+
+// envSubsections list of subsection, for section that unmarshaled into
+// map, that can be set using environment variables even if its not
+// exist in the ini file.
+// The other subsections are derived from the ini file.
+var envSubsections = map[string][]string{
+	`forwarder`: {
+		forwarderKindInfluxd,
+		forwarderKindQuestdb,
+		forwarderKindPostgresql,
+	},
+	`pii`: {piiDefault},
+}
+
+// envSeparator the separator of values for option that can be set
+// multiple times.
+// The new line is used since the comma may exist in the value, for
+// example in regular expression or filter expression.
+const envSeparator = "\n"
+
+// reEnvVar match the "${NAME}", or the escaped "$${", in the option
+// value.
+var reEnvVar = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// envEscape the escaped "${" in the option value.
+const envEscape = `$${`
+
+// envKey contains the ini section, subsection, and key that can be set
+// using environment variable.
+type envKey struct {
+	sec   string
+	sub   string
+	key   string
+	multi bool
+}
+
+// envName return the environment variable name for the key,
+// "HAMINER_" + SECTION + "_" + SUBSECTION + "_" + KEY, in upper case.
+// The section "haminer" is omitted, for example "haminer::listen" become
+// "HAMINER_LISTEN".
+func (ek envKey) envName() string {
+	var names []string
+	if ek.sec != `haminer` {
+		names = append(names, ek.sec)
+	}
+	if len(ek.sub) != 0 {
+		names = append(names, ek.sub)
+	}
+	names = append(names, ek.key)
+
+	var name = strings.ToUpper(strings.Join(names, `_`))
+	name = strings.ReplaceAll(name, `-`, `_`)
+	return envPrefix + name
+}
+
+// listEnvKey return all the ini keys in the Config.
+// The subsections of section that unmarshaled into map are taken from
+// envSubsections and from the ini file in.
+func listEnvKey(in *ini.Ini) (keys []envKey) {
+	var (
+		cfgType = reflect.TypeFor[Config]()
+
+		field reflect.StructField
+		sub   string
+		tags  []string
+		tag   string
+		x     int
+	)
+
+	for x = range cfgType.NumField() {
+		field = cfgType.Field(x)
+		tag = field.Tag.Get(`ini`)
+		if len(tag) == 0 {
+			continue
+		}
+		tags = ini.ParseTag(tag)
+
+		if field.Type.Kind() == reflect.Map {
+			// The map value is pointer to struct.
+			var elemType = field.Type.Elem().Elem()
+			for _, sub = range listEnvSubsection(in, tags[0]) {
+				keys = append(keys, listEnvKeyStruct(elemType, tags[0], sub)...)
+			}
+			continue
+		}
+
+		keys = append(keys, envKey{
+			sec:   tags[0],
+			sub:   tags[1],
+			key:   tags[2],
+			multi: field.Type.Kind() == reflect.Slice,
+		})
+	}
+	return keys
+}
+
+// listEnvSubsection return the subsections of section sec from
+// envSubsections and from the ini file in, without duplicate.
+func listEnvSubsection(in *ini.Ini, sec string) (subs []string) {
+	subs = slices.Clone(envSubsections[sec])
+
+	if in == nil {
+		return subs
+	}
+
+	var (
+		iniSec *ini.Section
+		sub    string
+	)
+	for _, iniSec = range in.Subs(sec) {
+		sub = iniSec.SubName()
+		if !slices.Contains(subs, sub) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// listEnvKeyStruct return the ini keys for struct typ in section sec and
+// subsection sub.
+func listEnvKeyStruct(typ reflect.Type, sec, sub string) (keys []envKey) {
+	var (
+		field reflect.StructField
+		tags  []string
+		tag   string
+		x     int
+	)
+	for x = range typ.NumField() {
+		field = typ.Field(x)
+		tag = field.Tag.Get(`ini`)
+		if len(tag) == 0 {
+			continue
+		}
+		tags = ini.ParseTag(tag)
+		keys = append(keys, envKey{
+			sec:   sec,
+			sub:   sub,
+			key:   tags[2],
+			multi: field.Type.Kind() == reflect.Slice,
+		})
+	}
+	return keys
+}
+
+// applyEnv override the options in the ini file with the value from
+// environment variables.
+// For option that can be set multiple times, the values are separated by
+// new line and replace all of the values in the file.
+func applyEnv(in *ini.Ini) {
+	var (
+		ek    envKey
+		val   string
+		vals  []string
+		found bool
+	)
+	for _, ek = range listEnvKey(in) {
+		val, found = os.LookupEnv(ek.envName())
+		if !found {
+			continue
+		}
+		if !ek.multi {
+			in.Set(ek.sec, ek.sub, ek.key, val)
+			continue
+		}
+		in.UnsetAll(ek.sec, ek.sub, ek.key)
+		vals = strings.Split(val, envSeparator)
+		for _, val = range vals {
+			val = strings.TrimSpace(val)
+			if len(val) != 0 {
+				in.Add(ek.sec, ek.sub, ek.key, val)
+			}
+		}
+	}
+}
+
+// expandEnv replace each "${NAME}" in the string with the value of
+// environment variable NAME, and each "$${" with literal "${".
+// It will return an error if the environment variable is not set.
+func expandEnv(in string) (out string, err error) {
+	out = reEnvVar.ReplaceAllStringFunc(in, func(match string) string {
+		if match == envEscape {
+			return match[1:]
+		}
+		var (
+			name    = match[2 : len(match)-1]
+			val, ok = os.LookupEnv(name)
+		)
+		if !ok && err == nil {
+			err = fmt.Errorf(`environment variable %q is not set`, name)
+		}
+		return val
+	})
+	return out, err
+}
+
+// expandEnvStruct expand the environment variables in all string and
+// slice of string fields, that has "ini" tag, in the struct pointed by v.
+// The fields in section "preprocess" are skipped, since its value is
+// regular expression that may contains "${name}".
+func expandEnvStruct(v any) (err error) {
+	var (
+		rv  = reflect.ValueOf(v).Elem()
+		typ = rv.Type()
+
+		field reflect.StructField
+		fv    reflect.Value
+		tag   string
+		x     int
+	)
+	for x = range typ.NumField() {
+		field = typ.Field(x)
+		tag = field.Tag.Get(`ini`)
+		if len(tag) == 0 || strings.HasPrefix(tag, `preprocess:`) {
+			continue
+		}
+		fv = rv.Field(x)
+
+		switch fv.Kind() {
+		case reflect.String:
+			var s string
+			s, err = expandEnv(fv.String())
+			if err != nil {
+				return fmt.Errorf(`%s: %w`, tag, err)
+			}
+			fv.SetString(s)
+
+		case reflect.Slice:
+			if fv.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+			var y int
+			for y = range fv.Len() {
+				var s string
+				s, err = expandEnv(fv.Index(y).String())
+				if err != nil {
+					return fmt.Errorf(`%s: %w`, tag, err)
+				}
+				fv.Index(y).SetString(s)
+			}
+		}
+	}
+	return nil
+}
+
+// readSecretFile return the content of file at path, without the
+// trailing spaces and new lines.
+func readSecretFile(path string) (secret string, err error) {
+	var content []byte
+
+	content, err = os.ReadFile(path)
+	if err != nil {
+		return ``, err
+	}
+	return strings.TrimRight(string(content), " \t\r\n"), nil
+}
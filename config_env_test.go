@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/ini"
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestEnvKey_envName(t *testing.T) {
+	var in, err = ini.Parse([]byte(`[sample "health-check"]
+rate = 0.1
+
+[pii "questdb"]
+client_ip = keep
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, ek := range listEnvKey(in) {
+		names = append(names, ek.envName())
+	}
+
+	var exps = []string{
+		`HAMINER_LISTEN`,
+		`HAMINER_ACCEPT_BACKEND`,
+		`HAMINER_PREPROCESS_TAG_HTTP_URL`,
+		`HAMINER_FORWARDER_INFLUXD_TOKEN`,
+		`HAMINER_FORWARDER_QUESTDB_URL`,
+		`HAMINER_FORWARDER_POSTGRESQL_URL_FILE`,
+		`HAMINER_PII_DEFAULT_CLIENT_IP`,
+		`HAMINER_PII_QUESTDB_CLIENT_IP`,
+		`HAMINER_SAMPLE_HEALTH_CHECK_RATE`,
+		`HAMINER_SAMPLE_HEALTH_CHECK_URL`,
+	}
+	for _, exp := range exps {
+		if !slices.Contains(names, exp) {
+			t.Errorf(`missing environment variable %s`, exp)
+		}
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv(`HAMINER_TEST_TOKEN`, `secret`)
+
+	var (
+		got string
+		err error
+	)
+
+	got, err = expandEnv(`Token ${HAMINER_TEST_TOKEN}, $1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `expandEnv`, `Token secret, $1`, got)
+
+	_, err = expandEnv(`${HAMINER_TEST_NOTEXIST}`)
+	test.Assert(t, `error`,
+		`environment variable "HAMINER_TEST_NOTEXIST" is not set`,
+		err.Error())
+
+	// The escaped "$${" become literal "${", even if the name is not
+	// set.
+	got, err = expandEnv(`pa$${HAMINER_TEST_NOTEXIST}ss, $${HAMINER_TEST_TOKEN}, a$$b, ${HAMINER_TEST_TOKEN}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `expandEnv escaped`,
+		`pa${HAMINER_TEST_NOTEXIST}ss, ${HAMINER_TEST_TOKEN}, a$$b, secret`, got)
+}
+
+func TestConfig_Load_env(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		cfgFile   = filepath.Join(dir, `haminer.conf`)
+		tokenFile = filepath.Join(dir, `influxd_token`)
+		content   = `[haminer]
+listen = ${HAMINER_TEST_ADDR}:5140
+accept_backend = a
+
+[forwarder "influxd"]
+version = v2
+url = http://127.0.0.1:8086
+org = kilabit.info
+bucket = haproxy
+user = haminer
+pass = pa$${word}
+token_file = ` + tokenFile + `
+
+[sample "health"]
+url = ^/health$
+rate = 0.5
+`
+	)
+
+	var err = os.WriteFile(cfgFile, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(tokenFile, []byte("s3cr3t\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(`HAMINER_TEST_ADDR`, `0.0.0.0`)
+	t.Setenv(`HAMINER_ACCEPT_BACKEND`, "b\n c")
+	t.Setenv(`HAMINER_FILTER`, `status_code in (500,502)`)
+	t.Setenv(`HAMINER_SAMPLE_HEALTH_EVERY`, `10`)
+	t.Setenv(`HAMINER_SAMPLE_HEALTH_RATE`, `0`)
+	t.Setenv(`HAMINER_FORWARDER_INFLUXD_BUCKET`, `logs`)
+
+	var cfg = NewConfig()
+
+	err = cfg.Load(cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test.Assert(t, `listenAddr`, `0.0.0.0`, cfg.listenAddr)
+	test.Assert(t, `AcceptBackend`, []string{`b`, `c`}, cfg.AcceptBackend)
+	test.Assert(t, `Filter`, []string{`status_code in (500,502)`}, cfg.Filter)
+	test.Assert(t, `Sample.Every`, int64(10), cfg.Sample[`health`].Every)
+
+	var fwCfg = cfg.Forwarders[forwarderKindInfluxd]
+	test.Assert(t, `Bucket`, `logs`, fwCfg.Bucket)
+	test.Assert(t, `Token`, `s3cr3t`, fwCfg.Token)
+	test.Assert(t, `headerToken`, `Token s3cr3t`, fwCfg.headerToken)
+	test.Assert(t, `Pass`, `pa${word}`, fwCfg.Pass)
+
+	// Setting both token and token_file is an error.
+	t.Setenv(`HAMINER_FORWARDER_INFLUXD_TOKEN`, `other`)
+
+	cfg = NewConfig()
+	err = cfg.Load(cfgFile)
+	test.Assert(t, `error`,
+		`Load: influxd: token and token_file cannot be set together`,
+		err.Error())
+}
@@ -5,8 +5,12 @@
 package haminer
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -18,15 +22,45 @@ const (
 	forwarderKindInfluxd    = `influxd`
 	forwarderKindQuestdb    = `questdb`
 	forwarderKindPostgresql = `postgresql`
+	forwarderKindSqlite     = `sqlite`
+
+	questdbSchemeHTTP  = `http`
+	questdbSchemeHTTPS = `https`
+	questdbSchemeTCP   = `tcp`
+	questdbSchemeUDP   = `udp`
+
+	defQuestdbPartitionBy = `DAY`
+
+	defPostgresqlChunkInterval  = `1d`
+	defPostgresqlPartitionAhead = 3
+
+	partitionByDay   = `DAY`
+	partitionByMonth = `MONTH`
 )
 
+// intervalUnits map the interval unit suffix into SQL time unit, as used
+// by Questdb and Postgresql.
+var intervalUnits = map[byte]string{
+	'h': `HOURS`,
+	'd': `DAYS`,
+	'w': `WEEKS`,
+	'M': `MONTHS`,
+	'y': `YEARS`,
+}
+
 // ConfigForwarder contains configuration for forwarding the logs.
 type ConfigForwarder struct {
 	kind    string
 	Version string `ini:"::version"`
 
-	URL         string `ini:"::url"`
-	apiWrite    string
+	URL      string `ini:"::url"`
+	apiWrite string
+
+	// URLFile the path to file that contains the URL.
+	// This is useful for URL that contains password, for example the
+	// Postgresql connection string.
+	URLFile string `ini:"::url_file"`
+
 	headerToken string
 
 	Bucket string `ini:"::bucket"`
@@ -36,27 +70,125 @@ type ConfigForwarder struct {
 	User string `ini:"::user"`
 	Pass string `ini:"::pass"`
 
+	// PassFile the path to file that contains the Pass.
+	PassFile string `ini:"::pass_file"`
+
 	// Fields for Influxd HTTP API v2.
 
 	Org   string `ini:"::org"`
 	Token string `ini:"::token"`
+
+	// TokenFile the path to file that contains the Token.
+	TokenFile string `ini:"::token_file"`
+
+	// Fields for Questdb.
+
+	// scheme of the URL, as in "udp", "tcp", "http", or "https".
+	scheme string
+
+	// ExecURL define the Questdb HTTP address to execute the DDL for
+	// creating table.
+	// If its empty and the URL scheme is "http" or "https", it will
+	// derived from URL.
+	ExecURL string `ini:"::exec_url"`
+	apiExec string
+
+	// PartitionBy define the table partition.
+	// For Questdb, default to "DAY".
+	// For Postgresql, the valid values is "DAY" or "MONTH", and empty
+	// means the table is not partitioned.
+	PartitionBy string `ini:"::partition_by"`
+
+	// TTL define how long the partition will be kept, in the format
+	// DIGITS ("h" / "d" / "w" / "M" / "y"), for example "30d".
+	TTL string `ini:"::ttl"`
+	ttl string
+
+	// Fields for Postgresql.
+
+	// ChunkInterval define the time range of each hypertable chunk,
+	// default to "1d".
+	ChunkInterval string `ini:"::chunk_interval"`
+	chunkInterval string
+
+	// CompressAfter define the age of chunk before its compressed.
+	CompressAfter string `ini:"::compress_after"`
+	compressAfter string
+
+	// Retention define the age of logs before its dropped.
+	Retention string `ini:"::retention"`
+	retention string
+
+	// PartitionAhead define number of partitions to be created ahead
+	// of current time, default to 3.
+	PartitionAhead int `ini:"::partition_ahead"`
+
+	// InsecureSkipVerify if true, the TLS certificate of the server
+	// will not be verified when using "https" scheme.
+	InsecureSkipVerify bool `ini:"::insecure_skip_verify"`
+
+	// PII the name of PII policy applied to the logs before forwarded.
+	// Default to "default", and "none" to disable it.
+	PII string `ini:"::pii"`
+
+	// TimescaleDB if true, the http_log table will be converted into
+	// TimescaleDB hypertable.
+	TimescaleDB bool `ini:"::timescaledb"`
 }
 
 // init check, validate, and initialize the configuration values.
 func (cfg *ConfigForwarder) init(fwName string) (err error) {
 	cfg.kind = fwName
 
+	err = cfg.readSecretFiles()
+	if err != nil {
+		return err
+	}
+
 	if len(cfg.URL) == 0 {
 		return
 	}
 
-	if fwName == forwarderKindInfluxd {
+	switch fwName {
+	case forwarderKindInfluxd:
 		return cfg.initInfluxd()
+	case forwarderKindQuestdb:
+		return cfg.initQuestdb()
+	case forwarderKindPostgresql:
+		return cfg.initPostgresql()
 	}
 
 	return nil
 }
 
+// readSecretFiles set the URL, Pass, and Token from the content of
+// URLFile, PassFile, and TokenFile.
+func (cfg *ConfigForwarder) readSecretFiles() (err error) {
+	var list = []struct {
+		val  *string
+		key  string
+		file string
+	}{
+		{&cfg.URL, `url`, cfg.URLFile},
+		{&cfg.Pass, `pass`, cfg.PassFile},
+		{&cfg.Token, `token`, cfg.TokenFile},
+	}
+	for _, secret := range list {
+		if len(secret.file) == 0 {
+			continue
+		}
+		if len(*secret.val) != 0 {
+			return fmt.Errorf(`%s and %s_file cannot be set together`,
+				secret.key, secret.key)
+		}
+		*secret.val, err = readSecretFile(secret.file)
+		if err != nil {
+			return fmt.Errorf(`%s_file: %w`, secret.key, err)
+		}
+	}
+	return nil
+}
+
 func (cfg *ConfigForwarder) initInfluxd() (err error) {
 	switch cfg.Version {
 	case influxdVersion1:
@@ -108,3 +240,170 @@ func (cfg *ConfigForwarder) initInfluxd() (err error) {
 
 	return nil
 }
+
+// initQuestdb parse the URL to select the transport.
+// For scheme "http" or "https", the logs will be written using ILP over
+// HTTP to path "/write", with optional authorization using token or
+// user and password.
+func (cfg *ConfigForwarder) initQuestdb() (err error) {
+	var surl *url.URL
+
+	surl, err = url.Parse(cfg.URL)
+	if err != nil {
+		return err
+	}
+
+	cfg.scheme = surl.Scheme
+	if len(cfg.scheme) == 0 {
+		cfg.scheme = questdbSchemeUDP
+	}
+
+	err = cfg.initQuestdbTable()
+	if err != nil {
+		return err
+	}
+
+	switch cfg.scheme {
+	case questdbSchemeUDP, questdbSchemeTCP:
+		if len(cfg.ExecURL) != 0 {
+			cfg.apiExec, err = questdbAPIExec(cfg.ExecURL)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case questdbSchemeHTTP, questdbSchemeHTTPS:
+	default:
+		return errors.New(`unknown scheme ` + cfg.scheme)
+	}
+
+	if len(cfg.Token) != 0 {
+		cfg.headerToken = `Bearer ` + cfg.Token
+	} else if len(cfg.User) != 0 {
+		var userPass = cfg.User + `:` + cfg.Pass
+		cfg.headerToken = `Basic ` +
+			base64.StdEncoding.EncodeToString([]byte(userPass))
+	}
+
+	var q = url.Values{}
+
+	q.Set(`precision`, `n`)
+
+	surl.Path = `/write`
+	surl.RawQuery = q.Encode()
+
+	cfg.apiWrite = surl.String()
+
+	if len(cfg.ExecURL) == 0 {
+		cfg.ExecURL = cfg.URL
+	}
+	cfg.apiExec, err = questdbAPIExec(cfg.ExecURL)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// initQuestdbTable validate the options for creating table.
+func (cfg *ConfigForwarder) initQuestdbTable() (err error) {
+	cfg.PartitionBy = strings.ToUpper(strings.TrimSpace(cfg.PartitionBy))
+
+	switch cfg.PartitionBy {
+	case ``:
+		cfg.PartitionBy = defQuestdbPartitionBy
+	case `HOUR`, `DAY`, `WEEK`, `MONTH`, `YEAR`:
+	default:
+		return fmt.Errorf(`invalid partition_by %q`, cfg.PartitionBy)
+	}
+
+	cfg.ttl, err = parseInterval(`ttl`, cfg.TTL)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// initPostgresql validate the options for TimescaleDB and partitioning.
+func (cfg *ConfigForwarder) initPostgresql() (err error) {
+	if len(cfg.ChunkInterval) == 0 {
+		cfg.ChunkInterval = defPostgresqlChunkInterval
+	}
+
+	cfg.chunkInterval, err = parseInterval(`chunk_interval`, cfg.ChunkInterval)
+	if err != nil {
+		return err
+	}
+	cfg.compressAfter, err = parseInterval(`compress_after`, cfg.CompressAfter)
+	if err != nil {
+		return err
+	}
+	cfg.retention, err = parseInterval(`retention`, cfg.Retention)
+	if err != nil {
+		return err
+	}
+
+	cfg.PartitionBy = strings.ToUpper(strings.TrimSpace(cfg.PartitionBy))
+
+	switch cfg.PartitionBy {
+	case ``:
+		return nil
+	case partitionByDay, partitionByMonth:
+	default:
+		return fmt.Errorf(`invalid partition_by %q`, cfg.PartitionBy)
+	}
+
+	if cfg.TimescaleDB {
+		return errors.New(`partition_by cannot be used with timescaledb`)
+	}
+	if cfg.PartitionAhead <= 0 {
+		cfg.PartitionAhead = defPostgresqlPartitionAhead
+	}
+	return nil
+}
+
+// parseInterval parse the interval value in the format
+// DIGITS ("h" / "d" / "w" / "M" / "y") into SQL interval, for example
+// "30d" become "30 DAYS".
+// The key parameter is the option name, used on error message.
+func parseInterval(key, val string) (interval string, err error) {
+	val = strings.TrimSpace(val)
+	if len(val) == 0 {
+		return ``, nil
+	}
+
+	var (
+		unit = val[len(val)-1]
+
+		n    int
+		name string
+		ok   bool
+	)
+
+	name, ok = intervalUnits[unit]
+	if !ok {
+		return ``, fmt.Errorf(`invalid %s %q: unknown unit`, key, val)
+	}
+
+	n, err = strconv.Atoi(val[:len(val)-1])
+	if err != nil || n <= 0 {
+		return ``, fmt.Errorf(`invalid %s %q`, key, val)
+	}
+
+	return fmt.Sprintf(`%d %s`, n, name), nil
+}
+
+// questdbAPIExec return the URL for Questdb HTTP API "/exec".
+func questdbAPIExec(rawurl string) (apiExec string, err error) {
+	var surl *url.URL
+
+	surl, err = url.Parse(rawurl)
+	if err != nil {
+		return ``, fmt.Errorf(`invalid exec_url: %w`, err)
+	}
+
+	surl.Path = `/exec`
+	surl.RawQuery = ``
+
+	return surl.String(), nil
+}
@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defDashboardWindow the number of seconds of logs aggregated in
+	// the dashboard.
+	defDashboardWindow = 60
+
+	// defDashboardTop the maximum number of entries in the top URLs
+	// and top client IPs.
+	defDashboardTop = 10
+)
+
+// dashboard aggregate the HTTPLog in the last defDashboardWindow seconds,
+// using one bucket for each second.
+type dashboard struct {
+	buckets [defDashboardWindow]*dashboardBucket
+	sync.Mutex
+}
+
+// dashboardBucket contains the aggregate of logs in one second.
+type dashboardBucket struct {
+	backend     map[string]int64
+	statusClass map[string]int64
+	termState   map[string]int64
+	url         map[string]int64
+	clientIP    map[string]int64
+
+	timeAll []int32
+
+	sec   int64
+	total int64
+}
+
+// dashboardStats contains the statistics published to the subscribers of
+// HTTP API apiDashboard.
+type dashboardStats struct {
+	Time time.Time `json:"time"`
+
+	// RPSBackend contains the average requests per second for each
+	// backend.
+	RPSBackend map[string]float64 `json:"rps_backend"`
+
+	// StatusClass contains the number of requests for each status
+	// class, for example "2xx" or "5xx".
+	StatusClass map[string]int64 `json:"status_class"`
+
+	// TermState contains the number of requests for each termination
+	// state.
+	TermState map[string]int64 `json:"term_state"`
+
+	TopURL      []dashboardCount `json:"top_url"`
+	TopClientIP []dashboardCount `json:"top_client_ip"`
+
+	// TimeAll contains the percentiles of TimeAll, in milliseconds.
+	TimeAll dashboardPercentile `json:"time_all"`
+
+	// Window the number of seconds aggregated.
+	Window int `json:"window"`
+
+	Requests int64 `json:"requests"`
+}
+
+type dashboardCount struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+type dashboardPercentile struct {
+	P50 int32 `json:"p50"`
+	P90 int32 `json:"p90"`
+	P99 int32 `json:"p99"`
+	Max int32 `json:"max"`
+}
+
+func newDashboardBucket(sec int64) (bucket *dashboardBucket) {
+	bucket = &dashboardBucket{
+		backend:     make(map[string]int64),
+		statusClass: make(map[string]int64),
+		termState:   make(map[string]int64),
+		url:         make(map[string]int64),
+		clientIP:    make(map[string]int64),
+		sec:         sec,
+	}
+	return bucket
+}
+
+// add the HTTPLog into bucket of time now.
+// The halog must be already pre-processed, so the top URLs are grouped
+// by the pre-processed URL.
+func (dash *dashboard) add(now time.Time, halog *HTTPLog) {
+	var (
+		sec = now.Unix()
+		idx = sec % defDashboardWindow
+	)
+
+	dash.Lock()
+	defer dash.Unlock()
+
+	var bucket = dash.buckets[idx]
+	if bucket == nil || bucket.sec != sec {
+		bucket = newDashboardBucket(sec)
+		dash.buckets[idx] = bucket
+	}
+
+	bucket.total++
+	bucket.backend[halog.BackendName]++
+	bucket.statusClass[statusClass(halog.StatusCode)]++
+	bucket.termState[halog.TerminationState]++
+	bucket.url[halog.tagHTTPURL]++
+	bucket.clientIP[halog.ClientIP]++
+
+	// Negative TimeAll means the request is aborted.
+	if halog.TimeAll >= 0 {
+		bucket.timeAll = append(bucket.timeAll, halog.TimeAll)
+	}
+}
+
+// stats return the statistics of logs in the last window seconds before
+// now, including the current second.
+func (dash *dashboard) stats(now time.Time) (stats dashboardStats) {
+	var (
+		sec = now.Unix()
+
+		urls     = make(map[string]int64)
+		clientIP = make(map[string]int64)
+		timeAll  []int32
+		key      string
+		n        int64
+	)
+
+	stats = dashboardStats{
+		Time:        now.Truncate(time.Second).UTC(),
+		Window:      defDashboardWindow,
+		RPSBackend:  make(map[string]float64),
+		StatusClass: make(map[string]int64),
+		TermState:   make(map[string]int64),
+	}
+
+	dash.Lock()
+	for _, bucket := range dash.buckets {
+		if bucket == nil || bucket.sec > sec || bucket.sec <= sec-defDashboardWindow {
+			continue
+		}
+
+		stats.Requests += bucket.total
+		for key, n = range bucket.backend {
+			stats.RPSBackend[key] += float64(n)
+		}
+		for key, n = range bucket.statusClass {
+			stats.StatusClass[key] += n
+		}
+		for key, n = range bucket.termState {
+			stats.TermState[key] += n
+		}
+		for key, n = range bucket.url {
+			urls[key] += n
+		}
+		for key, n = range bucket.clientIP {
+			clientIP[key] += n
+		}
+		timeAll = append(timeAll, bucket.timeAll...)
+	}
+	dash.Unlock()
+
+	for key = range stats.RPSBackend {
+		stats.RPSBackend[key] /= defDashboardWindow
+	}
+
+	stats.TopURL = topCount(urls, defDashboardTop)
+	stats.TopClientIP = topCount(clientIP, defDashboardTop)
+	stats.TimeAll = percentile(timeAll)
+
+	return stats
+}
+
+// statusClass return the class of HTTP status code, for example "2xx"
+// for 200.
+func statusClass(code int32) string {
+	if code < 100 || code > 599 {
+		return `other`
+	}
+	return strconv.Itoa(int(code/100)) + `xx`
+}
+
+// topCount return at most n entries with the highest count, sorted in
+// descending order by count and then ascending by key.
+func topCount(counts map[string]int64, n int) (list []dashboardCount) {
+	list = make([]dashboardCount, 0, len(counts))
+	for key, count := range counts {
+		list = append(list, dashboardCount{Key: key, Count: count})
+	}
+	sort.Slice(list, func(x, y int) bool {
+		if list[x].Count == list[y].Count {
+			return list[x].Key < list[y].Key
+		}
+		return list[x].Count > list[y].Count
+	})
+	if len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
+
+// percentile return the 50th, 90th, 99th percentiles and maximum values
+// using the nearest-rank method.
+// The values will be sorted in place.
+func percentile(values []int32) (pct dashboardPercentile) {
+	if len(values) == 0 {
+		return pct
+	}
+
+	sort.Slice(values, func(x, y int) bool {
+		return values[x] < values[y]
+	})
+
+	var rank = func(p int) int32 {
+		var idx = (p*len(values)+99)/100 - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return values[idx]
+	}
+
+	pct.P50 = rank(50)
+	pct.P90 = rank(90)
+	pct.P99 = rank(99)
+	pct.Max = values[len(values)-1]
+	return pct
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestDashboard_stats(t *testing.T) {
+	var (
+		now  = time.Date(2026, 1, 18, 5, 8, 28, 0, time.UTC)
+		dash = &dashboard{}
+	)
+
+	// This log is outside of window and should be ignored.
+	dash.add(now.Add(-defDashboardWindow*time.Second), &HTTPLog{
+		BackendName: `old`,
+		StatusCode:  200,
+	})
+
+	var listLog = []*HTTPLog{{
+		BackendName:      `api`,
+		ClientIP:         `10.0.0.1`,
+		tagHTTPURL:       `/users/:id`,
+		TerminationState: `----`,
+		StatusCode:       200,
+		TimeAll:          10,
+	}, {
+		BackendName:      `api`,
+		ClientIP:         `10.0.0.2`,
+		tagHTTPURL:       `/users/:id`,
+		TerminationState: `----`,
+		StatusCode:       201,
+		TimeAll:          20,
+	}, {
+		BackendName:      `web`,
+		ClientIP:         `10.0.0.1`,
+		tagHTTPURL:       `/`,
+		TerminationState: `SH--`,
+		StatusCode:       503,
+		TimeAll:          -1,
+	}}
+
+	var (
+		halog *HTTPLog
+		x     int
+	)
+	for x, halog = range listLog {
+		dash.add(now.Add(-time.Duration(x)*time.Second), halog)
+	}
+
+	var exp = dashboardStats{
+		Time: now,
+		RPSBackend: map[string]float64{
+			`api`: 2.0 / defDashboardWindow,
+			`web`: 1.0 / defDashboardWindow,
+		},
+		StatusClass: map[string]int64{
+			`2xx`: 2,
+			`5xx`: 1,
+		},
+		TermState: map[string]int64{
+			`----`: 2,
+			`SH--`: 1,
+		},
+		TopURL: []dashboardCount{
+			{Key: `/users/:id`, Count: 2},
+			{Key: `/`, Count: 1},
+		},
+		TopClientIP: []dashboardCount{
+			{Key: `10.0.0.1`, Count: 2},
+			{Key: `10.0.0.2`, Count: 1},
+		},
+		TimeAll: dashboardPercentile{
+			P50: 10,
+			P90: 20,
+			P99: 20,
+			Max: 20,
+		},
+		Window:   defDashboardWindow,
+		Requests: 3,
+	}
+
+	test.Assert(t, `stats`, exp, dash.stats(now))
+}
+
+func TestPercentile(t *testing.T) {
+	var values = make([]int32, 0, 100)
+	for x := int32(100); x > 0; x-- {
+		values = append(values, x)
+	}
+
+	var exp = dashboardPercentile{
+		P50: 50,
+		P90: 90,
+		P99: 99,
+		Max: 100,
+	}
+	test.Assert(t, `percentile`, exp, percentile(values))
+	test.Assert(t, `empty`, dashboardPercentile{}, percentile(nil))
+}
@@ -0,0 +1,697 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filterHeaderPrefix the prefix of field name to access the captured
+// request header, for example "header.host".
+const filterHeaderPrefix = `header.`
+
+// List of token in filter expression.
+const (
+	filterTokEOF = iota
+	filterTokWord
+	filterTokString
+	filterTokOp
+)
+
+// List of operators in filter expression.
+const (
+	filterOpEqual     = `==`
+	filterOpNotEqual  = `!=`
+	filterOpLess      = `<`
+	filterOpLessEq    = `<=`
+	filterOpGreater   = `>`
+	filterOpGreaterEq = `>=`
+	filterOpMatch     = `=~`
+	filterOpNotMatch  = `!~`
+	filterOpIn        = `in`
+	filterOpAnd       = `&&`
+	filterOpOr        = `||`
+	filterOpNot       = `!`
+)
+
+// filterOps list of operators, the two characters operators must be
+// checked first.
+var filterOps = []string{
+	filterOpEqual, filterOpNotEqual, filterOpLessEq, filterOpGreaterEq,
+	filterOpMatch, filterOpNotMatch, filterOpAnd, filterOpOr,
+	filterOpLess, filterOpGreater, filterOpNot, `(`, `)`, `,`,
+}
+
+// List of field kind in filter expression.
+const (
+	filterKindString = iota
+	filterKindNumber
+	filterKindBool
+)
+
+// filterField define how to get the value of HTTPLog field in filter
+// expression.
+type filterField struct {
+	getString func(*HTTPLog) string
+	getNumber func(*HTTPLog) int64
+	getBool   func(*HTTPLog) bool
+	kind      int
+}
+
+func filterString(get func(*HTTPLog) string) filterField {
+	return filterField{kind: filterKindString, getString: get}
+}
+
+func filterNumber[T int32 | int64](get func(*HTTPLog) T) filterField {
+	return filterField{
+		kind: filterKindNumber,
+		getNumber: func(halog *HTTPLog) int64 {
+			return int64(get(halog))
+		},
+	}
+}
+
+// filterFields list of HTTPLog field that can be used in filter
+// expression, named the same as the column in Postgresql.
+var filterFields = map[string]filterField{
+	`client_ip`:         filterString(func(l *HTTPLog) string { return l.ClientIP }),
+	`client_label`:      filterString(func(l *HTTPLog) string { return l.ClientLabel }),
+	`client_host`:       filterString(func(l *HTTPLog) string { return l.ClientHost }),
+	`geo_country`:       filterString(func(l *HTTPLog) string { return l.GeoCountry }),
+	`geo_city`:          filterString(func(l *HTTPLog) string { return l.GeoCity }),
+	`as_org`:            filterString(func(l *HTTPLog) string { return l.ASOrg }),
+	`ua_browser`:        filterString(func(l *HTTPLog) string { return l.UABrowser }),
+	`ua_os`:             filterString(func(l *HTTPLog) string { return l.UAOS }),
+	`ua_device`:         filterString(func(l *HTTPLog) string { return l.UADevice }),
+	`frontend_name`:     filterString(func(l *HTTPLog) string { return l.FrontendName }),
+	`backend_name`:      filterString(func(l *HTTPLog) string { return l.BackendName }),
+	`server_name`:       filterString(func(l *HTTPLog) string { return l.ServerName }),
+	`http_proto`:        filterString(func(l *HTTPLog) string { return l.HTTPProto }),
+	`http_method`:       filterString(func(l *HTTPLog) string { return l.HTTPMethod }),
+	`http_url`:          filterString(func(l *HTTPLog) string { return l.HTTPURL }),
+	`http_query`:        filterString(func(l *HTTPLog) string { return l.HTTPQuery }),
+	`cookie_request`:    filterString(func(l *HTTPLog) string { return l.CookieRequest }),
+	`cookie_response`:   filterString(func(l *HTTPLog) string { return l.CookieResponse }),
+	`termination_state`: filterString(func(l *HTTPLog) string { return l.TerminationState }),
+
+	`asn`:           filterNumber(func(l *HTTPLog) int64 { return l.ASN }),
+	`bytes_read`:    filterNumber(func(l *HTTPLog) int64 { return l.BytesRead }),
+	`status_code`:   filterNumber(func(l *HTTPLog) int32 { return l.StatusCode }),
+	`client_port`:   filterNumber(func(l *HTTPLog) int32 { return l.ClientPort }),
+	`time_request`:  filterNumber(func(l *HTTPLog) int32 { return l.TimeRequest }),
+	`time_wait`:     filterNumber(func(l *HTTPLog) int32 { return l.TimeWait }),
+	`time_connect`:  filterNumber(func(l *HTTPLog) int32 { return l.TimeConnect }),
+	`time_response`: filterNumber(func(l *HTTPLog) int32 { return l.TimeResponse }),
+	`time_all`:      filterNumber(func(l *HTTPLog) int32 { return l.TimeAll }),
+	`conn_active`:   filterNumber(func(l *HTTPLog) int32 { return l.ConnActive }),
+	`conn_frontend`: filterNumber(func(l *HTTPLog) int32 { return l.ConnFrontend }),
+	`conn_backend`:  filterNumber(func(l *HTTPLog) int32 { return l.ConnBackend }),
+	`conn_server`:   filterNumber(func(l *HTTPLog) int32 { return l.ConnServer }),
+	`retries`:       filterNumber(func(l *HTTPLog) int32 { return l.Retries }),
+	`server_queue`:  filterNumber(func(l *HTTPLog) int32 { return l.ServerQueue }),
+	`backend_queue`: filterNumber(func(l *HTTPLog) int32 { return l.BackendQueue }),
+
+	`is_bot`:   {kind: filterKindBool, getBool: func(l *HTTPLog) bool { return l.IsBot }},
+	`is_error`: {kind: filterKindBool, getBool: func(l *HTTPLog) bool { return l.IsError }},
+}
+
+// lookupFilterField return the field by its name, including the captured
+// request header "header.<name>".
+func lookupFilterField(name string) (field filterField, ok bool) {
+	field, ok = filterFields[name]
+	if ok {
+		return field, true
+	}
+	var hdrName, isHeader = strings.CutPrefix(name, filterHeaderPrefix)
+	if !isHeader || len(hdrName) == 0 {
+		return field, false
+	}
+	field = filterString(func(l *HTTPLog) string {
+		return l.HeaderRequest[hdrName]
+	})
+	return field, true
+}
+
+// filterExpr the compiled filter expression.
+type filterExpr interface {
+	eval(halog *HTTPLog) bool
+}
+
+type filterAnd struct {
+	left, right filterExpr
+}
+
+func (expr *filterAnd) eval(halog *HTTPLog) bool {
+	return expr.left.eval(halog) && expr.right.eval(halog)
+}
+
+type filterOr struct {
+	left, right filterExpr
+}
+
+func (expr *filterOr) eval(halog *HTTPLog) bool {
+	return expr.left.eval(halog) || expr.right.eval(halog)
+}
+
+type filterNot struct {
+	expr filterExpr
+}
+
+func (expr *filterNot) eval(halog *HTTPLog) bool {
+	return !expr.expr.eval(halog)
+}
+
+type filterBool struct {
+	get   func(*HTTPLog) bool
+	value bool
+}
+
+func (expr *filterBool) eval(halog *HTTPLog) bool {
+	return expr.get(halog) == expr.value
+}
+
+type filterCompareString struct {
+	get   func(*HTTPLog) string
+	op    string
+	value string
+}
+
+func (expr *filterCompareString) eval(halog *HTTPLog) bool {
+	var v = expr.get(halog)
+	switch expr.op {
+	case filterOpEqual:
+		return v == expr.value
+	case filterOpNotEqual:
+		return v != expr.value
+	case filterOpLess:
+		return v < expr.value
+	case filterOpLessEq:
+		return v <= expr.value
+	case filterOpGreater:
+		return v > expr.value
+	case filterOpGreaterEq:
+		return v >= expr.value
+	}
+	return false
+}
+
+type filterCompareNumber struct {
+	get   func(*HTTPLog) int64
+	op    string
+	value int64
+}
+
+func (expr *filterCompareNumber) eval(halog *HTTPLog) bool {
+	var v = expr.get(halog)
+	switch expr.op {
+	case filterOpEqual:
+		return v == expr.value
+	case filterOpNotEqual:
+		return v != expr.value
+	case filterOpLess:
+		return v < expr.value
+	case filterOpLessEq:
+		return v <= expr.value
+	case filterOpGreater:
+		return v > expr.value
+	case filterOpGreaterEq:
+		return v >= expr.value
+	}
+	return false
+}
+
+type filterRegex struct {
+	get    func(*HTTPLog) string
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (expr *filterRegex) eval(halog *HTTPLog) bool {
+	return expr.re.MatchString(expr.get(halog)) != expr.negate
+}
+
+type filterInString struct {
+	get    func(*HTTPLog) string
+	values map[string]struct{}
+}
+
+func (expr *filterInString) eval(halog *HTTPLog) bool {
+	var _, ok = expr.values[expr.get(halog)]
+	return ok
+}
+
+type filterInNumber struct {
+	get    func(*HTTPLog) int64
+	values map[int64]struct{}
+}
+
+func (expr *filterInNumber) eval(halog *HTTPLog) bool {
+	var _, ok = expr.values[expr.get(halog)]
+	return ok
+}
+
+type filterInCIDR struct {
+	get      func(*HTTPLog) string
+	prefixes []netip.Prefix
+}
+
+func (expr *filterInCIDR) eval(halog *HTTPLog) bool {
+	var addr, err = netip.ParseAddr(expr.get(halog))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range expr.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterToken the token in filter expression.
+type filterToken struct {
+	value string
+	kind  int
+	pos   int
+}
+
+// filterParser compile the filter expression using recursive descent,
+//
+//	expr       = and *("||" and)
+//	and        = unary *("&&" unary)
+//	unary      = "!" unary / primary
+//	primary    = "(" expr ")" / comparison / bool-field
+//	comparison = field ("==" / "!=" / "<" / "<=" / ">" / ">=") value
+//	comparison =/ field ("=~" / "!~") string
+//	comparison =/ field "in" (CIDR / "(" value *("," value) ")")
+type filterParser struct {
+	toks []filterToken
+	pos  int
+}
+
+// compileFilterExpr parse and compile the filter expression.
+func compileFilterExpr(in string) (expr filterExpr, err error) {
+	var parser = &filterParser{}
+
+	parser.toks, err = lexFilterExpr(in)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err = parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	var tok = parser.peek()
+	if tok.kind != filterTokEOF {
+		return nil, fmt.Errorf(`unexpected %q at %d`, tok.value, tok.pos)
+	}
+	return expr, nil
+}
+
+// compileFilterExprs compile list of filter expression.
+func compileFilterExprs(list []string) (exprs []filterExpr, err error) {
+	var expr filterExpr
+	for _, in := range list {
+		in = strings.TrimSpace(in)
+		if len(in) == 0 {
+			continue
+		}
+		expr, err = compileFilterExpr(in)
+		if err != nil {
+			return nil, fmt.Errorf(`%q: %w`, in, err)
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// lexFilterExpr split the filter expression into tokens.
+//
+// The string value can be single quoted, with two single quotes as the
+// escaped single quote, or double quoted using the Go syntax.
+// The single quote is the one to be used in the configuration file,
+// since the double quotes are removed by the ini parser.
+func lexFilterExpr(in string) (toks []filterToken, err error) {
+	var x int
+	for x < len(in) {
+		var c = in[x]
+		if c == ' ' || c == '\t' {
+			x++
+			continue
+		}
+
+		if c == '\'' {
+			var (
+				value string
+				n     int
+			)
+			value, n = unquoteFilterSingle(in[x:])
+			if n == 0 {
+				return nil, fmt.Errorf(`invalid string at %d`, x)
+			}
+			toks = append(toks, filterToken{kind: filterTokString, value: value, pos: x})
+			x += n
+			continue
+		}
+
+		if c == '"' {
+			var quoted string
+			quoted, err = strconv.QuotedPrefix(in[x:])
+			if err != nil {
+				return nil, fmt.Errorf(`invalid string at %d`, x)
+			}
+			var value, _ = strconv.Unquote(quoted)
+			toks = append(toks, filterToken{kind: filterTokString, value: value, pos: x})
+			x += len(quoted)
+			continue
+		}
+
+		var op = matchFilterOp(in[x:])
+		if len(op) != 0 {
+			toks = append(toks, filterToken{kind: filterTokOp, value: op, pos: x})
+			x += len(op)
+			continue
+		}
+
+		var start = x
+		for x < len(in) && isFilterWordChar(in[x]) {
+			x++
+		}
+		if start == x {
+			return nil, fmt.Errorf(`unexpected character %q at %d`, c, x)
+		}
+		toks = append(toks, filterToken{kind: filterTokWord, value: in[start:x], pos: start})
+	}
+	toks = append(toks, filterToken{kind: filterTokEOF, pos: len(in)})
+	return toks, nil
+}
+
+// unquoteFilterSingle return the value of single quoted string in the
+// prefix of in and the length of quoted string.
+// It return zero length if the string is not terminated.
+func unquoteFilterSingle(in string) (value string, n int) {
+	var sb strings.Builder
+	var x = 1
+	for x < len(in) {
+		if in[x] != '\'' {
+			sb.WriteByte(in[x])
+			x++
+			continue
+		}
+		if x+1 < len(in) && in[x+1] == '\'' {
+			sb.WriteByte('\'')
+			x += 2
+			continue
+		}
+		return sb.String(), x + 1
+	}
+	return ``, 0
+}
+
+func matchFilterOp(in string) string {
+	for _, op := range filterOps {
+		if strings.HasPrefix(in, op) {
+			return op
+		}
+	}
+	return ``
+}
+
+// isFilterWordChar return true if c is part of field name, number, IP
+// address, or CIDR.
+func isFilterWordChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9') || c == '_' || c == '.' || c == ':' ||
+		c == '/' || c == '-'
+}
+
+func (parser *filterParser) peek() filterToken {
+	return parser.toks[parser.pos]
+}
+
+func (parser *filterParser) next() (tok filterToken) {
+	tok = parser.toks[parser.pos]
+	if tok.kind != filterTokEOF {
+		parser.pos++
+	}
+	return tok
+}
+
+func (parser *filterParser) isOp(op string) bool {
+	var tok = parser.peek()
+	return tok.kind == filterTokOp && tok.value == op
+}
+
+func (parser *filterParser) parseOr() (expr filterExpr, err error) {
+	expr, err = parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for parser.isOp(filterOpOr) {
+		parser.next()
+
+		var right filterExpr
+
+		right, err = parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		expr = &filterOr{left: expr, right: right}
+	}
+	return expr, nil
+}
+
+func (parser *filterParser) parseAnd() (expr filterExpr, err error) {
+	expr, err = parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for parser.isOp(filterOpAnd) {
+		parser.next()
+
+		var right filterExpr
+
+		right, err = parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		expr = &filterAnd{left: expr, right: right}
+	}
+	return expr, nil
+}
+
+func (parser *filterParser) parseUnary() (expr filterExpr, err error) {
+	if parser.isOp(filterOpNot) {
+		parser.next()
+		expr, err = parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{expr: expr}, nil
+	}
+	return parser.parsePrimary()
+}
+
+func (parser *filterParser) parsePrimary() (expr filterExpr, err error) {
+	if parser.isOp(`(`) {
+		parser.next()
+		expr, err = parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		var tok = parser.next()
+		if tok.kind != filterTokOp || tok.value != `)` {
+			return nil, fmt.Errorf(`expecting ")" at %d`, tok.pos)
+		}
+		return expr, nil
+	}
+
+	var tok = parser.next()
+	if tok.kind != filterTokWord {
+		return nil, fmt.Errorf(`expecting field at %d`, tok.pos)
+	}
+
+	var field, ok = lookupFilterField(tok.value)
+	if !ok {
+		return nil, fmt.Errorf(`unknown field %q at %d`, tok.value, tok.pos)
+	}
+
+	var opTok = parser.peek()
+	switch {
+	case opTok.kind == filterTokWord && opTok.value == filterOpIn:
+		parser.next()
+		return parser.parseIn(field)
+	case opTok.kind == filterTokOp:
+		switch opTok.value {
+		case filterOpAnd, filterOpOr, `)`:
+		default:
+			parser.next()
+			return parser.parseComparison(field, opTok)
+		}
+	}
+
+	if field.kind != filterKindBool {
+		return nil, fmt.Errorf(`expecting operator after %q at %d`,
+			tok.value, opTok.pos)
+	}
+	return &filterBool{get: field.getBool, value: true}, nil
+}
+
+func (parser *filterParser) parseComparison(field filterField, opTok filterToken) (
+	expr filterExpr, err error,
+) {
+	var (
+		op  = opTok.value
+		tok = parser.next()
+	)
+
+	switch op {
+	case filterOpMatch, filterOpNotMatch:
+		if field.kind != filterKindString || tok.kind != filterTokString {
+			return nil, fmt.Errorf(`operator %s require string field and value at %d`,
+				op, opTok.pos)
+		}
+		var re *regexp.Regexp
+		re, err = regexp.Compile(tok.value)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid regex at %d: %w`, tok.pos, err)
+		}
+		return &filterRegex{get: field.getString, re: re, negate: op == filterOpNotMatch}, nil
+
+	case filterOpEqual, filterOpNotEqual, filterOpLess, filterOpLessEq,
+		filterOpGreater, filterOpGreaterEq:
+	default:
+		return nil, fmt.Errorf(`unexpected %q at %d`, op, opTok.pos)
+	}
+
+	switch field.kind {
+	case filterKindString:
+		if tok.kind != filterTokString && tok.kind != filterTokWord {
+			return nil, fmt.Errorf(`expecting value at %d`, tok.pos)
+		}
+		return &filterCompareString{get: field.getString, op: op, value: tok.value}, nil
+
+	case filterKindNumber:
+		var value int64
+		value, err = parseFilterNumber(tok)
+		if err != nil {
+			return nil, err
+		}
+		return &filterCompareNumber{get: field.getNumber, op: op, value: value}, nil
+	}
+
+	// The field is bool.
+	var value bool
+	if tok.kind == filterTokWord {
+		value, err = strconv.ParseBool(tok.value)
+	}
+	if tok.kind != filterTokWord || err != nil {
+		return nil, fmt.Errorf(`expecting true or false at %d`, tok.pos)
+	}
+	switch op {
+	case filterOpEqual:
+	case filterOpNotEqual:
+		value = !value
+	default:
+		return nil, fmt.Errorf(`operator %s require number or string field at %d`,
+			op, opTok.pos)
+	}
+	return &filterBool{get: field.getBool, value: value}, nil
+}
+
+// parseIn parse the value of operator "in", either single CIDR or list of
+// values inside parentheses.
+func (parser *filterParser) parseIn(field filterField) (expr filterExpr, err error) {
+	var values []filterToken
+
+	if parser.isOp(`(`) {
+		parser.next()
+		for {
+			var tok = parser.next()
+			if tok.kind != filterTokWord && tok.kind != filterTokString {
+				return nil, fmt.Errorf(`expecting value at %d`, tok.pos)
+			}
+			values = append(values, tok)
+
+			tok = parser.next()
+			if tok.kind == filterTokOp && tok.value == `)` {
+				break
+			}
+			if tok.kind != filterTokOp || tok.value != `,` {
+				return nil, fmt.Errorf(`expecting "," or ")" at %d`, tok.pos)
+			}
+		}
+	} else {
+		var tok = parser.next()
+		if tok.kind != filterTokWord || !strings.Contains(tok.value, `/`) {
+			return nil, fmt.Errorf(`expecting CIDR or list at %d`, tok.pos)
+		}
+		values = append(values, tok)
+	}
+
+	switch field.kind {
+	case filterKindNumber:
+		var in = &filterInNumber{
+			get:    field.getNumber,
+			values: make(map[int64]struct{}, len(values)),
+		}
+		for _, tok := range values {
+			var value int64
+			value, err = parseFilterNumber(tok)
+			if err != nil {
+				return nil, err
+			}
+			in.values[value] = struct{}{}
+		}
+		return in, nil
+
+	case filterKindString:
+		if values[0].kind == filterTokWord && strings.Contains(values[0].value, `/`) {
+			return parseFilterInCIDR(field, values)
+		}
+		var in = &filterInString{
+			get:    field.getString,
+			values: make(map[string]struct{}, len(values)),
+		}
+		for _, tok := range values {
+			in.values[tok.value] = struct{}{}
+		}
+		return in, nil
+	}
+	return nil, errors.New(`operator in require number or string field`)
+}
+
+func parseFilterInCIDR(field filterField, values []filterToken) (expr *filterInCIDR, err error) {
+	expr = &filterInCIDR{
+		get: field.getString,
+	}
+	for _, tok := range values {
+		var prefix netip.Prefix
+		prefix, err = netip.ParsePrefix(tok.value)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid CIDR at %d: %w`, tok.pos, err)
+		}
+		expr.prefixes = append(expr.prefixes, prefix.Masked())
+	}
+	return expr, nil
+}
+
+func parseFilterNumber(tok filterToken) (v int64, err error) {
+	if tok.kind == filterTokWord {
+		v, err = strconv.ParseInt(tok.value, 10, 64)
+	}
+	if tok.kind != filterTokWord || err != nil {
+		return 0, fmt.Errorf(`expecting number at %d`, tok.pos)
+	}
+	return v, nil
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestCompileFilterExpr(t *testing.T) {
+	var halog = &HTTPLog{
+		HeaderRequest: map[string]string{
+			`host`: `api.example.com`,
+		},
+		ClientIP:         `10.1.2.3`,
+		BackendName:      `be-api`,
+		HTTPMethod:       `GET`,
+		HTTPURL:          `/health/live`,
+		TerminationState: `----`,
+		StatusCode:       503,
+		IsBot:            true,
+	}
+
+	var cases = []struct {
+		in  string
+		exp bool
+	}{{
+		in:  `status_code >= 400 && http_method != "OPTIONS"`,
+		exp: true,
+	}, {
+		in:  `status_code < 400 || http_method == "OPTIONS"`,
+		exp: false,
+	}, {
+		in:  `http_url =~ "^/health"`,
+		exp: true,
+	}, {
+		in:  `http_url !~ "^/health"`,
+		exp: false,
+	}, {
+		in:  `client_ip in 10.0.0.0/8`,
+		exp: true,
+	}, {
+		in:  `client_ip in (192.168.0.0/16, 172.16.0.0/12)`,
+		exp: false,
+	}, {
+		in:  `!(client_ip in 10.0.0.0/8)`,
+		exp: false,
+	}, {
+		in:  `http_url =~ '^/health' && http_method != 'OPTIONS'`,
+		exp: true,
+	}, {
+		in:  `http_method in ("GET", "HEAD")`,
+		exp: true,
+	}, {
+		in:  `http_method in ('POST', 'HEAD')`,
+		exp: false,
+	}, {
+		in:  `termination_state != 'it''s'`,
+		exp: true,
+	}, {
+		in:  `status_code in (500, 502, 503)`,
+		exp: true,
+	}, {
+		in:  `backend_name == be-api`,
+		exp: true,
+	}, {
+		in:  `header.host == "api.example.com"`,
+		exp: true,
+	}, {
+		in:  `is_bot`,
+		exp: true,
+	}, {
+		in:  `is_bot == false`,
+		exp: false,
+	}, {
+		in:  `!is_bot || termination_state != "----"`,
+		exp: false,
+	}, {
+		// The "&&" has higher precedence than "||".
+		in:  `http_method == "POST" && status_code >= 500 || backend_name == "be-api"`,
+		exp: true,
+	}, {
+		in:  `http_method == "POST" && (status_code >= 500 || backend_name == "be-api")`,
+		exp: false,
+	}}
+
+	for _, c := range cases {
+		var expr, err = compileFilterExpr(c.in)
+		if err != nil {
+			t.Fatalf(`%s: %s`, c.in, err)
+		}
+		test.Assert(t, c.in, c.exp, expr.eval(halog))
+	}
+}
+
+func TestCompileFilterExpr_error(t *testing.T) {
+	var cases = []struct {
+		in     string
+		expErr string
+	}{{
+		in:     `status == 200`,
+		expErr: `unknown field "status" at 0`,
+	}, {
+		in:     `status_code >= "ok"`,
+		expErr: `expecting number at 15`,
+	}, {
+		in:     `http_url =~ "("`,
+		expErr: "invalid regex at 12: error parsing regexp: missing closing ): `(`",
+	}, {
+		in:     `status_code =~ "^5"`,
+		expErr: `operator =~ require string field and value at 12`,
+	}, {
+		in:     `http_url`,
+		expErr: `expecting operator after "http_url" at 8`,
+	}, {
+		in:     `(status_code == 200`,
+		expErr: `expecting ")" at 19`,
+	}, {
+		in:     `client_ip in 10.0.0.0`,
+		expErr: `expecting CIDR or list at 13`,
+	}, {
+		in:     `status_code == 200 http_method`,
+		expErr: `unexpected "http_method" at 19`,
+	}, {
+		in:     `http_url == "/a`,
+		expErr: `invalid string at 12`,
+	}, {
+		in:     `http_url == '/a`,
+		expErr: `invalid string at 12`,
+	}, {
+		in:     `http_url == '/a''`,
+		expErr: `invalid string at 12`,
+	}}
+
+	for _, c := range cases {
+		var _, err = compileFilterExpr(c.in)
+		if err == nil {
+			t.Fatalf(`%s: expecting error`, c.in)
+		}
+		test.Assert(t, c.in, c.expErr, err.Error())
+	}
+}
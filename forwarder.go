@@ -4,8 +4,140 @@
 
 package haminer
 
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
+)
+
 // Forwarder define an interface to forward parsed HAProxy log to storage
 // engine.
 type Forwarder interface {
 	Forwards(halogs []*HTTPLog)
 }
+
+// forwarderChecker define the Forwarder that can report the error when
+// forwarding the logs.
+type forwarderChecker interface {
+	Forwarder
+	forward(halogs []*HTTPLog) error
+}
+
+// forwarderCloser define the Forwarder that need to release its resources
+// when its no longer used, for example after configuration reloaded.
+type forwarderCloser interface {
+	close() error
+}
+
+// closeForwarder release the resources of fw, if its implement
+// forwarderCloser.
+func closeForwarder(fw Forwarder) {
+	var closer, ok = fw.(forwarderCloser)
+	if !ok {
+		return
+	}
+	var err = closer.close()
+	if err != nil {
+		mlog.Errf(`closeForwarder: %s: %s`, forwarderName(fw), err)
+	}
+}
+
+// forwarderName return the kind of forwarder.
+func forwarderName(fw Forwarder) string {
+	switch fw.(type) {
+	case *forwarderInfluxd:
+		return forwarderKindInfluxd
+	case *forwarderQuestdb:
+		return forwarderKindQuestdb
+	case *forwarderPostgresql:
+		return forwarderKindPostgresql
+	case *forwarderSqlite:
+		return forwarderKindSqlite
+	}
+	return fmt.Sprintf(`%T`, fw)
+}
+
+// newTestHTTPLog create synthetic HTTPLog to test the forwarders.
+func newTestHTTPLog(now time.Time) (halog *HTTPLog) {
+	halog = &HTTPLog{
+		RequestDate:      now.UTC(),
+		ClientIP:         `127.0.0.1`,
+		FrontendName:     `haminer-test`,
+		BackendName:      `haminer-test`,
+		ServerName:       `haminer-test`,
+		HTTPProto:        `HTTP/1.1`,
+		HTTPMethod:       `GET`,
+		HTTPURL:          `/haminer/test-forwarders`,
+		tagHTTPURL:       `/haminer/test-forwarders`,
+		tagClientIP:      `127.0.0.1`,
+		tagServerName:    `haminer-test`,
+		tagBackendName:   `haminer-test`,
+		CookieRequest:    `-`,
+		CookieResponse:   `-`,
+		TerminationState: `----`,
+		StatusCode:       200,
+		SampleWeight:     1,
+	}
+	return halog
+}
+
+// CheckForwarders send one synthetic log, with backend "haminer-test", to
+// each forwarder in the cfg and print the result to out.
+// It will return an error if one of the forwarder failed.
+//
+// The database schema is not created nor migrated.
+// If the Postgresql or SQLite database has pending migrations, it will
+// return an error.
+func CheckForwarders(cfg *Config, out io.Writer) (err error) {
+	var (
+		logp = `CheckForwarders`
+		h    = &Haminer{
+			cfg:       cfg,
+			checkOnly: true,
+		}
+	)
+
+	err = h.createForwarder()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	defer h.closeForwarders()
+
+	if len(h.ff) == 0 {
+		return fmt.Errorf(`%s: no forwarder configured`, logp)
+	}
+
+	var (
+		halogs = []*HTTPLog{newTestHTTPLog(time.Now())}
+
+		fw      Forwarder
+		checker forwarderChecker
+		name    string
+		nfailed int
+		ok      bool
+	)
+	for _, fw = range h.ff {
+		name = forwarderName(fw)
+
+		checker, ok = fw.(forwarderChecker)
+		if !ok {
+			fw.Forwards(cfg.piiPolicy(name).apply(halogs))
+			fmt.Fprintf(out, "%s: sent\n", name)
+			continue
+		}
+
+		err = checker.forward(cfg.piiPolicy(name).apply(halogs))
+		if err != nil {
+			fmt.Fprintf(out, "%s: FAIL: %s\n", name, err)
+			nfailed++
+			continue
+		}
+		fmt.Fprintf(out, "%s: OK\n", name)
+	}
+	if nfailed != 0 {
+		return fmt.Errorf(`%s: %d forwarder(s) failed`, logp, nfailed)
+	}
+	return nil
+}
@@ -65,26 +65,30 @@ func (cl *forwarderInfluxd) initConn() {
 // Forwards implement the Forwarder interface. It will write all logs to
 // Influxd.
 func (cl *forwarderInfluxd) Forwards(halogs []*HTTPLog) {
-	var (
-		logp = `influxdClient: Forwards`
+	var err = cl.forward(halogs)
+	if err != nil {
+		log.Printf(`influxdClient: Forwards: %s`, err)
+	}
+}
 
+// forward write all logs to Influxd and return the error if the request
+// failed or the response status is not 2xx.
+func (cl *forwarderInfluxd) forward(halogs []*HTTPLog) (err error) {
+	var (
 		httpReq *http.Request
 		httpRes *http.Response
-		err     error
 	)
 
 	err = cl.write(halogs)
 	if err != nil {
-		log.Printf(`%s: %s`, logp, err)
-		return
+		return err
 	}
 
 	var ctx = context.Background()
 
 	httpReq, err = http.NewRequestWithContext(ctx, http.MethodPost, cl.cfg.apiWrite, &cl.buf)
 	if err != nil {
-		log.Printf(`%s: %s`, logp, err)
-		return
+		return err
 	}
 
 	httpReq.Header.Set(`Accept`, `application/json`)
@@ -98,27 +102,22 @@ func (cl *forwarderInfluxd) Forwards(halogs []*HTTPLog) {
 
 	httpRes, err = cl.conn.Do(httpReq)
 	if err != nil {
-		log.Printf(`%s: %s`, logp, err)
-		return
+		return err
 	}
+	defer httpRes.Body.Close()
 
-	if httpRes.StatusCode >= 200 || httpRes.StatusCode <= 299 {
-		return
+	if httpRes.StatusCode >= 200 && httpRes.StatusCode <= 299 {
+		return nil
 	}
 
-	defer func() {
-		err = httpRes.Body.Close()
-		if err != nil {
-			log.Printf(`%s: Body.Close: %s`, logp, err)
-		}
-	}()
+	var rspBody []byte
 
-	rspBody, err := io.ReadAll(httpRes.Body)
+	rspBody, err = io.ReadAll(httpRes.Body)
 	if err != nil {
-		log.Printf(`%s: %s`, logp, err)
+		return err
 	}
 
-	fmt.Printf(`%s: response: %d %s\n`, logp, httpRes.StatusCode, rspBody)
+	return fmt.Errorf(`response: %d %s`, httpRes.StatusCode, rspBody)
 }
 
 func (cl *forwarderInfluxd) write(halogs []*HTTPLog) (err error) {
@@ -136,29 +135,38 @@ func (cl *forwarderInfluxd) write(halogs []*HTTPLog) (err error) {
 		_, err = fmt.Fprintf(&cl.buf, influxdTags,
 			// tags
 			cl.hostname,
-			l.ServerName,
-			l.BackendName,
+			l.tagServerName,
+			l.tagBackendName,
 			l.FrontendName,
 			l.HTTPMethod,
-			l.HTTPURL,
-			l.HTTPQuery,
+			l.tagHTTPURL,
 			l.HTTPProto,
 			l.StatusCode,
 			l.TerminationState,
-			l.ClientIP,
+			l.tagClientIP,
 			l.ClientPort,
 		)
 		if err != nil {
 			return err
 		}
 
-		for k, v = range l.HeaderRequest {
+		err = l.writeIlpTagHTTPQuery(&cl.buf)
+		if err != nil {
+			return err
+		}
+
+		for k, v = range l.tagHeaderRequest {
 			_, err = fmt.Fprintf(&cl.buf, ",%s=%s", k, v)
 			if err != nil {
 				return err
 			}
 		}
 
+		err = l.writeIlpEnrichTags(&cl.buf)
+		if err != nil {
+			return err
+		}
+
 		cl.buf.WriteByte(' ')
 
 		_, err = fmt.Fprintf(&cl.buf, influxdFields,
@@ -168,11 +176,17 @@ func (cl *forwarderInfluxd) write(halogs []*HTTPLog) (err error) {
 			l.ConnServer, l.Retries,
 			l.ServerQueue, l.BackendQueue,
 			l.BytesRead,
+			l.SampleWeight,
 		)
 		if err != nil {
 			return err
 		}
 
+		err = l.writeIlpEnrichFields(&cl.buf)
+		if err != nil {
+			return err
+		}
+
 		_, err = fmt.Fprintf(&cl.buf, " %d\n", l.RequestDate.UnixNano())
 		if err != nil {
 			return err
@@ -181,3 +195,9 @@ func (cl *forwarderInfluxd) write(halogs []*HTTPLog) (err error) {
 
 	return nil
 }
+
+// close the idle connections to Influxd.
+func (cl *forwarderInfluxd) close() (err error) {
+	cl.conn.CloseIdleConnections()
+	return nil
+}
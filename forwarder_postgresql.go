@@ -7,6 +7,7 @@ package haminer
 import (
 	"database/sql"
 	"fmt"
+	"sync"
 
 	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
 	libsql "git.sr.ht/~shulhan/pakakeh.go/lib/sql"
@@ -16,13 +17,40 @@ import (
 // forwarderPostgresql the client to write logs to Postgresql database.
 type forwarderPostgresql struct {
 	conn *libsql.Client
+
+	// reqHeaders contains the names of captured request headers, used
+	// to convert the old logs.
+	reqHeaders []string
+
+	// stopq stop the partitionMaintainer when the forwarder closed.
+	stopq chan struct{}
+
+	// partitions the known partitions of http_log, if the table is
+	// partitioned.
+	partitions []pgPartition
+
+	cfg ConfigForwarder
+
+	partMtx sync.Mutex
+
+	// noDDL if true, the missing partitions are not created when
+	// forwarding the logs, used when checking the forwarder.
+	noDDL bool
 }
 
 // newForwarderPostgresql create new forwarder for Postgresql.
 func newForwarderPostgresql(cfg ConfigForwarder) (fw *forwarderPostgresql, err error) {
 	var logp = `newForwarderPostgresql`
 
-	fw = &forwarderPostgresql{}
+	err = cfg.initPostgresql()
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	fw = &forwarderPostgresql{
+		cfg:   cfg,
+		stopq: make(chan struct{}),
+	}
 
 	var opts = libsql.ClientOptions{
 		DriverName: libsql.DriverNamePostgres,
@@ -37,19 +65,106 @@ func newForwarderPostgresql(cfg ConfigForwarder) (fw *forwarderPostgresql, err e
 	return fw, nil
 }
 
-// Forwards insert the list of HTTP log into the Postgresql.
-func (fw *forwarderPostgresql) Forwards(listLog []*HTTPLog) {
+// migrate the database schema using the embedded SQL files and, if its
+// enabled, setup the TimescaleDB or the table partitions.
+//
+// It will return [ErrSchemaNewer] if the database has been migrated by
+// newer version of haminer.
+func (fw *forwarderPostgresql) migrate() (err error) {
+	var logp = `migrate`
+
+	_, err = fw.pendingMigration()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = fw.migrateSchema()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	if fw.cfg.TimescaleDB {
+		err = fw.setupTimescaledb()
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+	}
+
+	if len(fw.cfg.PartitionBy) != 0 {
+		err = fw.setupPartition()
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+		err = fw.maintainPartition()
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+	}
+	return nil
+}
+
+// checkSchema return an error if the database schema has pending
+// migrations or, if partition_by is set, the http_log table is not
+// partitioned yet.
+// Unlike migrate, it does not modify the database.
+func (fw *forwarderPostgresql) checkSchema() (err error) {
 	var (
-		logp = `Forwards`
+		logp = `checkSchema`
 
-		sqltx *sql.Tx
-		err   error
+		pending []migrationFile
 	)
 
+	pending, err = fw.pendingMigration()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	if len(pending) != 0 {
+		return fmt.Errorf(`%s: %d pending migration(s) from %s,`+
+			` run "haminer migrate" first`,
+			logp, len(pending), pending[0].name)
+	}
+
+	if len(fw.cfg.PartitionBy) == 0 {
+		return nil
+	}
+
+	var relkind string
+
+	err = fw.conn.QueryRow(`SELECT relkind FROM pg_class WHERE oid = $1::regclass;`,
+		tableNameHTTPLog).Scan(&relkind)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	if relkind != `p` {
+		return fmt.Errorf(`%s: table %s is not partitioned yet,`+
+			` run "haminer migrate" first`, logp, tableNameHTTPLog)
+	}
+	return nil
+}
+
+// Forwards insert the list of HTTP log into the Postgresql.
+func (fw *forwarderPostgresql) Forwards(listLog []*HTTPLog) {
+	var err = fw.forward(listLog)
+	if err != nil {
+		mlog.Errf(`Forwards: %s`, err)
+	}
+}
+
+// forward insert all logs into table http_log in single transaction and
+// return the error if any.
+func (fw *forwarderPostgresql) forward(listLog []*HTTPLog) (err error) {
+	if len(fw.cfg.PartitionBy) != 0 && !fw.noDDL {
+		listLog, err = fw.ensurePartition(listLog)
+		if err != nil {
+			return err
+		}
+	}
+
+	var sqltx *sql.Tx
+
 	sqltx, err = fw.conn.Begin()
 	if err != nil {
-		mlog.Errf(`%s: %s`, logp, err)
-		return
+		return err
 	}
 
 	var (
@@ -85,31 +200,22 @@ func (fw *forwarderPostgresql) Forwards(listLog []*HTTPLog) {
 
 	err = stmt.Close()
 	if err != nil {
-		mlog.Errf(`%s: %s`, logp, err)
 		_ = sqltx.Rollback()
-		return
-	}
-
-	err = sqltx.Commit()
-	if err != nil {
-		mlog.Errf(`%s: %s`, logp, err)
-		return
+		return err
 	}
 
-	return
+	return sqltx.Commit()
 
 failed:
-	mlog.Errf(`%s: %s`, logp, err)
-
 	if stmt != nil {
-		err = stmt.Close() //nolint:sqlclosecheck
-		if err != nil {
-			mlog.Errf(`%s: %s`, logp, err)
-		}
+		_ = stmt.Close() //nolint:sqlclosecheck
 	}
+	_ = sqltx.Rollback()
+	return err
+}
 
-	err = sqltx.Rollback()
-	if err != nil {
-		mlog.Errf(`%s: %s`, logp, err)
-	}
+// close stop the partition maintainer and close the database connection.
+func (fw *forwarderPostgresql) close() (err error) {
+	close(fw.stopq)
+	return fw.conn.Close()
 }
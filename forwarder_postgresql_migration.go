@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/memfs"
+)
+
+// tableNameMigration the table where the state of migration saved by
+// [libsql.Client.Migrate].
+const tableNameMigration = `_migration`
+
+// settingCaptureRequestHeader the name of session setting that contains
+// the comma separated names of captured request headers, used by the
+// migration files.
+const settingCaptureRequestHeader = `haminer.capture_request_header`
+
+// ErrSchemaNewer returned by forwarder postgresql when the database
+// schema has been migrated by the newer version of haminer.
+var ErrSchemaNewer = errors.New(`database schema is newer than haminer`)
+
+// ErrCompressedChunk returned by forwarder postgresql when the migration
+// failed and the http_log hypertable has compressed chunks.
+var ErrCompressedChunk = errors.New(`http_log hypertable has compressed chunks`)
+
+// defBackfillBatch the number of rows updated at once when backfilling
+// the column id.
+const defBackfillBatch = 10000
+
+// migrationFile contains the name and content of SQL file for migration.
+type migrationFile struct {
+	name    string
+	content []byte
+}
+
+// listMigrationFile return all SQL files in directory dir of mfs sorted
+// by name.
+func listMigrationFile(mfs *memfs.MemFS, dir string) (list []migrationFile, err error) {
+	var (
+		logp = `listMigrationFile`
+
+		root  *memfs.Node
+		names []string
+		fis   []os.FileInfo
+	)
+
+	root, err = mfs.Get(dir)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	fis, err = root.Readdir(0)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	var fi os.FileInfo
+	for _, fi = range fis {
+		if !fi.Mode().IsRegular() {
+			continue
+		}
+		if !strings.HasSuffix(fi.Name(), `.sql`) {
+			continue
+		}
+		names = append(names, fi.Name())
+	}
+	sort.Strings(names)
+
+	var (
+		node *memfs.Node
+		name string
+	)
+	for _, name = range names {
+		node, err = mfs.Get(path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
+		}
+		list = append(list, migrationFile{
+			name:    name,
+			content: node.Content,
+		})
+	}
+	return list, nil
+}
+
+// pendingMigration return the list of migration files that has not been
+// applied to the database, by comparing the last applied file in the
+// migration table with the embedded SQL files.
+//
+// It will return [ErrSchemaNewer] if the last applied file is not known
+// by this version.
+func pendingMigration(lastFile string, list []migrationFile) (pending []migrationFile, err error) {
+	if len(lastFile) == 0 {
+		return list, nil
+	}
+
+	var (
+		mfile migrationFile
+		x     int
+	)
+	for x, mfile = range list {
+		if mfile.name == lastFile {
+			return list[x+1:], nil
+		}
+	}
+	return nil, fmt.Errorf(`%w: last migration %q is unknown`,
+		ErrSchemaNewer, lastFile)
+}
+
+// lastMigration return the last file name that has been applied to the
+// database, or empty string if no migration has been applied.
+func (fw *forwarderPostgresql) lastMigration() (lastFile string, err error) {
+	var (
+		logp = `lastMigration`
+
+		regclass sql.NullString
+	)
+
+	err = fw.conn.QueryRow(`SELECT to_regclass($1)::text;`,
+		tableNameMigration).Scan(&regclass)
+	if err != nil {
+		return ``, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	if !regclass.Valid {
+		return ``, nil
+	}
+
+	var q = `SELECT filename FROM ` + tableNameMigration +
+		` ORDER BY filename DESC LIMIT 1;`
+
+	err = fw.conn.QueryRow(q).Scan(&lastFile)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ``, nil
+		}
+		return ``, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return lastFile, nil
+}
+
+// pendingMigration return list of migration files that will be applied.
+func (fw *forwarderPostgresql) pendingMigration() (pending []migrationFile, err error) {
+	var (
+		logp = `pendingMigration`
+
+		list     []migrationFile
+		lastFile string
+	)
+
+	list, err = listMigrationFile(memfsDatabase, `/`)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	lastFile, err = fw.lastMigration()
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	pending, err = pendingMigration(lastFile, list)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return pending, nil
+}
+
+// migrateSchema apply the embedded SQL files.
+//
+// The names of captured request headers is set in the session setting
+// "haminer.capture_request_header", to be used by migration
+// "0008_http_log_header_raw.sql".
+// Since the setting only available in the same session, the connection
+// is limited to one during migration.
+func (fw *forwarderPostgresql) migrateSchema() (err error) {
+	var logp = `migrateSchema`
+
+	fw.conn.SetMaxOpenConns(1)
+	defer fw.conn.SetMaxOpenConns(0)
+
+	_, err = fw.conn.Exec(`SELECT set_config($1, $2, false);`,
+		settingCaptureRequestHeader, strings.Join(fw.reqHeaders, `,`))
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = fw.conn.Migrate(tableNameMigration, memfsDatabase)
+	if err != nil {
+		if fw.hasCompressedChunk() {
+			return fmt.Errorf(`%s: %w: %w; decompress the chunks using`+
+				` "SELECT decompress_chunk(c, true) FROM show_chunks('%s') c;"`+
+				` and run "haminer migrate"`,
+				logp, ErrCompressedChunk, err, tableNameHTTPLog)
+		}
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	_, err = fw.conn.Exec(`SELECT set_config($1, '', false);`,
+		settingCaptureRequestHeader)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return nil
+}
+
+// hasCompressedChunk return true if the http_log is TimescaleDB
+// hypertable that has compressed chunks.
+func (fw *forwarderPostgresql) hasCompressedChunk() bool {
+	var (
+		regclass sql.NullString
+		ok       bool
+	)
+
+	var err = fw.conn.QueryRow(`SELECT to_regclass($1)::text;`,
+		`timescaledb_information.chunks`).Scan(&regclass)
+	if err != nil || !regclass.Valid {
+		return false
+	}
+
+	err = fw.conn.QueryRow(`SELECT EXISTS (SELECT 1`+
+		` FROM timescaledb_information.chunks`+
+		` WHERE hypertable_name = $1 AND is_compressed);`,
+		tableNameHTTPLog).Scan(&ok)
+	if err != nil {
+		return false
+	}
+	return ok
+}
+
+// backfillID set the column id of the existing logs, that added before
+// migration "0009_http_log_id.sql", in batch.
+// Each batch is committed on its own, so the rows is locked only during
+// the batch.
+func (fw *forwarderPostgresql) backfillID(out io.Writer) (err error) {
+	var (
+		logp = `backfillID`
+		q    = fmt.Sprintf(`UPDATE %[1]s SET id = nextval('%[1]s_id_seq')`+
+			` WHERE (tableoid, ctid) IN (SELECT tableoid, ctid`+
+			` FROM %[1]s WHERE id IS NULL LIMIT %[2]d);`,
+			tableNameHTTPLog, defBackfillBatch)
+
+		res   sql.Result
+		n     int64
+		total int64
+	)
+
+	for {
+		res, err = fw.conn.Exec(q)
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+		n, err = res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+		if n == 0 {
+			break
+		}
+		total += n
+		fmt.Fprintf(out, "-- backfill id: %d rows\n", total)
+	}
+	return nil
+}
+
+// Migrate run the database migration on each forwarder that support it,
+// without starting haminer.
+//
+// If dryRun is true, the pending SQL will be printed to out without
+// applied to the database.
+// If backfillID is true, the column id of the existing logs in
+// PostgreSQL is set after the migration completed.
+//
+// It will return [ErrSchemaNewer] if the database schema is newer than
+// this version of haminer.
+func Migrate(cfg *Config, dryRun, backfillID bool, out io.Writer) (err error) {
+	var (
+		logp      = `Migrate`
+		pgCfg     = cfg.Forwarders[forwarderKindPostgresql]
+		sqliteCfg = cfg.Forwarders[forwarderKindSqlite]
+		isPg      = pgCfg != nil && len(pgCfg.URL) != 0
+		isSqlite  = sqliteCfg != nil && len(sqliteCfg.URL) != 0
+	)
+
+	if !isPg && !isSqlite {
+		fmt.Fprintln(out, `-- no forwarder with database migration`)
+		return nil
+	}
+
+	if isPg {
+		err = migratePostgresql(cfg, *pgCfg, dryRun, backfillID, out)
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+	}
+	if isSqlite {
+		err = migrateSqlite(*sqliteCfg, dryRun, out)
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+	}
+	return nil
+}
+
+// migratePostgresql run the migration on the postgresql forwarder.
+func migratePostgresql(cfg *Config, fwCfg ConfigForwarder, dryRun, backfillID bool, out io.Writer) (err error) {
+	var (
+		logp = `migratePostgresql`
+
+		pgc *forwarderPostgresql
+	)
+
+	pgc, err = newForwarderPostgresql(fwCfg)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	defer pgc.conn.Close()
+
+	pgc.reqHeaders = cfg.RequestHeaders
+
+	if !dryRun {
+		err = pgc.migrate()
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+		fmt.Fprintln(out, `-- migration completed`)
+
+		if backfillID {
+			err = pgc.backfillID(out)
+			if err != nil {
+				return fmt.Errorf(`%s: %w`, logp, err)
+			}
+			fmt.Fprintln(out, `-- backfill id completed`)
+		}
+		return nil
+	}
+
+	var pending []migrationFile
+
+	pending, err = pgc.pendingMigration()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	printMigration(out, pending)
+	return nil
+}
+
+// printMigration print the name and content of pending migration files
+// into out.
+func printMigration(out io.Writer, pending []migrationFile) {
+	if len(pending) == 0 {
+		fmt.Fprintln(out, `-- no pending migration`)
+		return
+	}
+
+	var mfile migrationFile
+	for _, mfile = range pending {
+		fmt.Fprintf(out, "-- %s\n%s\n", mfile.name, mfile.content)
+	}
+}
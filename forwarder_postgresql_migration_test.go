@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"errors"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestListMigrationFile(t *testing.T) {
+	var (
+		list []migrationFile
+		err  error
+	)
+
+	list, err = listMigrationFile(memfsDatabase, `/`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, mfile := range list {
+		names = append(names, mfile.name)
+	}
+
+	test.Assert(t, `first file`, `0001_http_log.sql`, names[0])
+
+	list, err = listMigrationFile(memfsDatabase, dirMigrationSqlite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `first sqlite file`, `0001_http_log.sql`, list[0].name)
+}
+
+func TestPendingMigration(t *testing.T) {
+	type testCase struct {
+		desc     string
+		lastFile string
+		exp      []migrationFile
+		expError error
+	}
+
+	var list = []migrationFile{{
+		name: `0001_a.sql`,
+	}, {
+		name: `0002_b.sql`,
+	}, {
+		name: `0003_c.sql`,
+	}}
+
+	var cases = []testCase{{
+		desc: `With empty database`,
+		exp:  list,
+	}, {
+		desc:     `With partial migration`,
+		lastFile: `0002_b.sql`,
+		exp:      list[2:],
+	}, {
+		desc:     `With complete migration`,
+		lastFile: `0003_c.sql`,
+		exp:      []migrationFile{},
+	}, {
+		desc:     `With newer schema`,
+		lastFile: `0004_d.sql`,
+		expError: ErrSchemaNewer,
+	}}
+
+	var (
+		c   testCase
+		got []migrationFile
+		err error
+	)
+	for _, c = range cases {
+		t.Log(c.desc)
+
+		got, err = pendingMigration(c.lastFile, list)
+		if err != nil {
+			test.Assert(t, `error`, true, errors.Is(err, c.expError))
+			continue
+		}
+		test.Assert(t, `pending`, c.exp, got)
+	}
+}
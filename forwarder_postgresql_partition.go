@@ -0,0 +1,552 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
+)
+
+const (
+	// defPartitionMaintenanceInterval define the interval to create the
+	// upcoming partitions and drop the old partitions.
+	defPartitionMaintenanceInterval = time.Hour
+
+	// partitionNameLegacy the name of partition for the logs that
+	// stored before the table is partitioned.
+	partitionNameLegacy = tableNameHTTPLog + `_legacy`
+
+	partitionBoundLayout = `2006-01-02 15:04:05`
+)
+
+// rePartitionBound match the partition bound returned by pg_get_expr,
+// for example "FOR VALUES FROM ('2026-01-18 00:00:00+00') TO (...)".
+var rePartitionBound = regexp.MustCompile(
+	`FROM \((?:'([^']+)'|MINVALUE)\) TO \((?:'([^']+)'|MAXVALUE)\)`)
+
+// pgPartition contains the name and range of the http_log partition.
+// The zero From means MINVALUE and the zero To means MAXVALUE.
+type pgPartition struct {
+	From time.Time
+	To   time.Time
+	Name string
+}
+
+// partitionStart return the start of period of t in UTC.
+func partitionStart(t time.Time, by string) time.Time {
+	t = t.UTC()
+	if by == partitionByMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// partitionNext return the start of the next period after start.
+func partitionNext(start time.Time, by string) time.Time {
+	if by == partitionByMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// partitionName return the name of partition that start at time start,
+// for example "http_log_p20260118" for DAY or "http_log_p202601" for
+// MONTH.
+func partitionName(start time.Time, by string) string {
+	if by == partitionByMonth {
+		return tableNameHTTPLog + `_p` + start.Format(`200601`)
+	}
+	return tableNameHTTPLog + `_p` + start.Format(`20060102`)
+}
+
+// partitionsToCreate return list of partitions for the current period
+// and the next ahead periods that does not overlap with the existing
+// partitions.
+func partitionsToCreate(now time.Time, by string, ahead int, existing []pgPartition) (list []pgPartition) {
+	var (
+		end = partitionStart(now, by)
+		x   int
+	)
+	for x = 0; x < ahead; x++ {
+		end = partitionNext(end, by)
+	}
+	return partitionsInRange(now, end, by, existing)
+}
+
+// partitionsInRange return list of partitions for each period from the
+// period of "from" until the period of "to", that does not overlap with
+// the existing partitions.
+func partitionsInRange(from, to time.Time, by string, existing []pgPartition) (list []pgPartition) {
+	var (
+		start = partitionStart(from, by)
+		end   = partitionStart(to, by)
+
+		part pgPartition
+	)
+	for !start.After(end) {
+		part = pgPartition{
+			Name: partitionName(start, by),
+			From: start,
+			To:   partitionNext(start, by),
+		}
+		if !part.isOverlap(existing) {
+			list = append(list, part)
+		}
+		start = part.To
+	}
+	return list
+}
+
+// partitionsToDrop return list of partitions where all of its logs is
+// older than cutoff.
+func partitionsToDrop(cutoff time.Time, existing []pgPartition) (list []pgPartition) {
+	var part pgPartition
+	for _, part = range existing {
+		if part.To.IsZero() {
+			continue
+		}
+		if !part.To.After(cutoff) {
+			list = append(list, part)
+		}
+	}
+	return list
+}
+
+// partitionsInRetention return list of partitions that still contains
+// logs newer than cutoff.
+// If cutoff is zero, it will return the list as is.
+func partitionsInRetention(cutoff time.Time, list []pgPartition) (kept []pgPartition) {
+	if cutoff.IsZero() {
+		return list
+	}
+	var part pgPartition
+	for _, part = range list {
+		if part.To.IsZero() || part.To.After(cutoff) {
+			kept = append(kept, part)
+		}
+	}
+	return kept
+}
+
+// logsInPartitions return the logs whose request_date is in one of the
+// partitions.
+func logsInPartitions(listLog []*HTTPLog, partitions []pgPartition) (kept []*HTTPLog) {
+	var (
+		halog *HTTPLog
+		part  pgPartition
+	)
+	kept = make([]*HTTPLog, 0, len(listLog))
+	for _, halog = range listLog {
+		for _, part = range partitions {
+			if part.contains(halog.RequestDate) {
+				kept = append(kept, halog)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// contains return true if t is in the range of partition.
+func (part pgPartition) contains(t time.Time) bool {
+	if !part.From.IsZero() && t.Before(part.From) {
+		return false
+	}
+	if !part.To.IsZero() && !t.Before(part.To) {
+		return false
+	}
+	return true
+}
+
+func (part pgPartition) isOverlap(existing []pgPartition) bool {
+	var other pgPartition
+	for _, other = range existing {
+		if !other.To.IsZero() && !other.To.After(part.From) {
+			continue
+		}
+		if !other.From.IsZero() && !other.From.Before(part.To) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// parsePartitionBound parse the partition bound expression into the
+// partition range.
+func parsePartitionBound(name, bound string) (part pgPartition, err error) {
+	var match = rePartitionBound.FindStringSubmatch(bound)
+	if len(match) != 3 {
+		return part, fmt.Errorf(`%s: unknown partition bound %q`, name, bound)
+	}
+
+	part.Name = name
+
+	part.From, err = parsePartitionTime(match[1])
+	if err != nil {
+		return part, fmt.Errorf(`%s: %w`, name, err)
+	}
+	part.To, err = parsePartitionTime(match[2])
+	if err != nil {
+		return part, fmt.Errorf(`%s: %w`, name, err)
+	}
+	return part, nil
+}
+
+func parsePartitionTime(v string) (t time.Time, err error) {
+	if len(v) == 0 {
+		return t, nil
+	}
+
+	var layout string
+	for _, layout = range []string{
+		partitionBoundLayout + `-07`,
+		partitionBoundLayout + `-07:00`,
+	} {
+		t, err = time.Parse(layout, v)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return t, err
+}
+
+func formatPartitionTime(t time.Time) string {
+	return t.UTC().Format(partitionBoundLayout) + `+00`
+}
+
+// setupPartition convert the http_log table into partitioned table, if
+// its not partitioned yet.
+//
+// The existing logs is kept in partition "http_log_legacy", from
+// MINVALUE to the start of the next period.
+func (fw *forwarderPostgresql) setupPartition() (err error) {
+	var (
+		logp = `setupPartition`
+
+		relkind string
+	)
+
+	err = fw.conn.QueryRow(`SELECT relkind FROM pg_class WHERE oid = $1::regclass;`,
+		tableNameHTTPLog).Scan(&relkind)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	if relkind == `p` {
+		return nil
+	}
+
+	var (
+		next = partitionNext(partitionStart(time.Now(), fw.cfg.PartitionBy),
+			fw.cfg.PartitionBy)
+
+		listq = []string{
+			`ALTER INDEX IF EXISTS http_log_idx RENAME TO http_log_legacy_idx;`,
+			`ALTER INDEX IF EXISTS http_log_date_id_idx RENAME TO http_log_legacy_date_id_idx;`,
+			`ALTER INDEX IF EXISTS http_log_time_idx RENAME TO http_log_legacy_time_idx;`,
+			`ALTER INDEX IF EXISTS http_log_header_request_idx RENAME TO http_log_legacy_header_request_idx;`,
+			`ALTER INDEX IF EXISTS http_log_header_response_idx RENAME TO http_log_legacy_header_response_idx;`,
+			`ALTER INDEX IF EXISTS http_log_geo_idx RENAME TO http_log_legacy_geo_idx;`,
+
+			fmt.Sprintf(`ALTER TABLE %s RENAME TO %s;`,
+				tableNameHTTPLog, partitionNameLegacy),
+
+			fmt.Sprintf(`CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS)`+
+				` PARTITION BY RANGE (request_date);`,
+				tableNameHTTPLog, partitionNameLegacy),
+
+			// The sequence of column id is owned by the new
+			// table, so its not dropped with the legacy partition.
+			fmt.Sprintf(`ALTER SEQUENCE IF EXISTS %s_id_seq OWNED BY %s.id;`,
+				tableNameHTTPLog, tableNameHTTPLog),
+
+			fmt.Sprintf(`ALTER TABLE %s ATTACH PARTITION %s`+
+				` FOR VALUES FROM (MINVALUE) TO ('%s');`,
+				tableNameHTTPLog, partitionNameLegacy,
+				formatPartitionTime(next)),
+
+			`CREATE INDEX IF NOT EXISTS http_log_idx ON http_log(
+  request_date
+, client_ip
+, frontend_name
+, backend_name
+, server_name
+, http_proto
+, http_method
+, http_url
+, termination_state
+, status_code
+);`,
+			`CREATE INDEX IF NOT EXISTS http_log_date_id_idx ON http_log(
+  request_date DESC
+, id DESC
+);`,
+			`CREATE INDEX IF NOT EXISTS http_log_time_idx ON http_log(
+  time_request
+, time_wait
+, time_connect
+, time_response
+, time_all
+);`,
+			`CREATE INDEX IF NOT EXISTS http_log_header_request_idx
+  ON http_log USING GIN (header_request);`,
+			`CREATE INDEX IF NOT EXISTS http_log_header_response_idx
+  ON http_log USING GIN (header_response);`,
+			`CREATE INDEX IF NOT EXISTS http_log_geo_idx ON http_log(
+  geo_country
+, asn
+);`,
+		}
+
+		tx *sql.Tx
+		q  string
+	)
+
+	tx, err = fw.conn.Begin()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	for _, q = range listq {
+		_, err = tx.Exec(q)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf(`%s: %q: %w`, logp, q, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	mlog.Outf(`%s: table %s has been partitioned by %s`, logp,
+		tableNameHTTPLog, fw.cfg.PartitionBy)
+
+	return nil
+}
+
+// listPartition fetch all partitions of http_log.
+func (fw *forwarderPostgresql) listPartition() (list []pgPartition, err error) {
+	var (
+		logp = `listPartition`
+		q    = `SELECT c.relname, pg_get_expr(c.relpartbound, c.oid)
+			FROM pg_inherits i
+			JOIN pg_class c ON c.oid = i.inhrelid
+			WHERE i.inhparent = $1::regclass;`
+
+		rows *sql.Rows
+	)
+
+	rows, err = fw.conn.Query(q, tableNameHTTPLog)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	defer rows.Close()
+
+	var (
+		part  pgPartition
+		name  string
+		bound string
+	)
+	for rows.Next() {
+		err = rows.Scan(&name, &bound)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
+		}
+
+		part, err = parsePartitionBound(name, bound)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
+		}
+		list = append(list, part)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return list, nil
+}
+
+// createPartitions create each partition in the list and add it into
+// the known partitions.
+// This method must be called with partMtx locked.
+func (fw *forwarderPostgresql) createPartitions(list []pgPartition) (err error) {
+	var (
+		part pgPartition
+		q    string
+	)
+	for _, part = range list {
+		q = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF %s`+
+			` FOR VALUES FROM ('%s') TO ('%s');`,
+			part.Name, tableNameHTTPLog,
+			formatPartitionTime(part.From), formatPartitionTime(part.To))
+
+		_, err = fw.conn.Exec(q)
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, part.Name, err)
+		}
+		fw.partitions = append(fw.partitions, part)
+	}
+	return nil
+}
+
+// ensurePartition create the partitions for the range of request_date
+// in listLog, if its not exist yet, so the old logs, for example from
+// replay or after downtime, can be inserted.
+//
+// The partition that all of its logs older than retention is not
+// created, and the logs that does not have partition are skipped.
+// It will return the list of logs that can be inserted.
+func (fw *forwarderPostgresql) ensurePartition(listLog []*HTTPLog) (kept []*HTTPLog, err error) {
+	if len(listLog) == 0 {
+		return listLog, nil
+	}
+
+	var (
+		logp = `ensurePartition`
+		from = listLog[0].RequestDate
+		to   = from
+
+		halog *HTTPLog
+	)
+	for _, halog = range listLog[1:] {
+		if halog.RequestDate.Before(from) {
+			from = halog.RequestDate
+		}
+		if halog.RequestDate.After(to) {
+			to = halog.RequestDate
+		}
+	}
+
+	fw.partMtx.Lock()
+	defer fw.partMtx.Unlock()
+
+	var list = partitionsInRange(from, to, fw.cfg.PartitionBy, fw.partitions)
+	if len(list) == 0 {
+		return listLog, nil
+	}
+
+	// The known partitions may be outdated, refresh them before
+	// creating the new one.
+	fw.partitions, err = fw.listPartition()
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	var cutoff time.Time
+
+	cutoff, err = fw.retentionCutoff()
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	list = partitionsInRange(from, to, fw.cfg.PartitionBy, fw.partitions)
+	list = partitionsInRetention(cutoff, list)
+
+	err = fw.createPartitions(list)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	kept = logsInPartitions(listLog, fw.partitions)
+	if len(kept) != len(listLog) {
+		mlog.Outf(`%s: %d log(s) older than retention skipped`, logp,
+			len(listLog)-len(kept))
+	}
+	return kept, nil
+}
+
+// retentionCutoff return the time where the logs older than it are
+// dropped, or zero time if retention is not set.
+func (fw *forwarderPostgresql) retentionCutoff() (cutoff time.Time, err error) {
+	if len(fw.cfg.retention) == 0 {
+		return cutoff, nil
+	}
+
+	var q = fmt.Sprintf(`SELECT now() - INTERVAL '%s';`, fw.cfg.retention)
+
+	err = fw.conn.QueryRow(q).Scan(&cutoff)
+	if err != nil {
+		return cutoff, fmt.Errorf(`retentionCutoff: %w`, err)
+	}
+	return cutoff, nil
+}
+
+// maintainPartition create the upcoming partitions and, if retention is
+// set, drop the partitions that older than retention.
+func (fw *forwarderPostgresql) maintainPartition() (err error) {
+	var (
+		logp = `maintainPartition`
+
+		part pgPartition
+	)
+
+	fw.partMtx.Lock()
+	defer fw.partMtx.Unlock()
+
+	fw.partitions, err = fw.listPartition()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = fw.createPartitions(partitionsToCreate(time.Now(),
+		fw.cfg.PartitionBy, fw.cfg.PartitionAhead, fw.partitions))
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	if len(fw.cfg.retention) == 0 {
+		return nil
+	}
+
+	var cutoff time.Time
+
+	cutoff, err = fw.retentionCutoff()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	for _, part = range partitionsToDrop(cutoff, fw.partitions) {
+		_, err = fw.conn.Exec(`DROP TABLE IF EXISTS ` + part.Name + `;`)
+		if err != nil {
+			return fmt.Errorf(`%s: %s: %w`, logp, part.Name, err)
+		}
+		mlog.Outf(`%s: partition %s dropped`, logp, part.Name)
+	}
+
+	fw.partitions, err = fw.listPartition()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return nil
+}
+
+// partitionMaintainer run maintainPartition periodically in the
+// background.
+func (fw *forwarderPostgresql) partitionMaintainer() {
+	var (
+		logp   = `partitionMaintainer`
+		ticker = time.NewTicker(defPartitionMaintenanceInterval)
+
+		err error
+	)
+	for {
+		select {
+		case <-ticker.C:
+			err = fw.maintainPartition()
+			if err != nil {
+				mlog.Errf(`%s: %s`, logp, err)
+			}
+		case <-fw.stopq:
+			ticker.Stop()
+			return
+		}
+	}
+}
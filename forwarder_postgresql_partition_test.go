@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"testing"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestParsePartitionBound(t *testing.T) {
+	type testCase struct {
+		desc     string
+		bound    string
+		exp      pgPartition
+		expError string
+	}
+
+	var cases = []testCase{{
+		desc:  `With MINVALUE`,
+		bound: `FOR VALUES FROM (MINVALUE) TO ('2026-01-19 00:00:00+00')`,
+		exp: pgPartition{
+			Name: `p`,
+			To:   time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		desc:  `With time zone offset`,
+		bound: `FOR VALUES FROM ('2026-01-18 07:00:00+07') TO ('2026-01-19 05:30:00+05:30')`,
+		exp: pgPartition{
+			Name: `p`,
+			From: time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		desc:     `With unknown bound`,
+		bound:    `DEFAULT`,
+		expError: `p: unknown partition bound "DEFAULT"`,
+	}}
+
+	var (
+		c   testCase
+		got pgPartition
+		err error
+	)
+	for _, c = range cases {
+		t.Log(c.desc)
+
+		got, err = parsePartitionBound(`p`, c.bound)
+		if err != nil {
+			test.Assert(t, `error`, c.expError, err.Error())
+			continue
+		}
+		test.Assert(t, `pgPartition`, c.exp, got)
+	}
+}
+
+func TestPartitionsToCreate(t *testing.T) {
+	var (
+		now      = time.Date(2026, 1, 18, 10, 0, 0, 0, time.UTC)
+		existing = []pgPartition{{
+			Name: partitionNameLegacy,
+			To:   time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260120`,
+			From: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC),
+		}}
+		exp = []pgPartition{{
+			Name: `http_log_p20260119`,
+			From: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260121`,
+			From: time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 22, 0, 0, 0, 0, time.UTC),
+		}}
+	)
+
+	var got = partitionsToCreate(now, partitionByDay, 3, existing)
+	test.Assert(t, `DAY`, exp, got)
+
+	exp = []pgPartition{{
+		Name: `http_log_p202601`,
+		From: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		Name: `http_log_p202602`,
+		From: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	got = partitionsToCreate(now, partitionByMonth, 1, nil)
+	test.Assert(t, `MONTH`, exp, got)
+}
+
+func TestPartitionsToDrop(t *testing.T) {
+	var (
+		cutoff   = time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC)
+		existing = []pgPartition{{
+			Name: partitionNameLegacy,
+			To:   time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260119`,
+			From: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260120`,
+			From: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC),
+		}}
+	)
+
+	var got = partitionsToDrop(cutoff, existing)
+
+	test.Assert(t, `partitionsToDrop`, existing[:2], got)
+}
+
+func TestPartitionsInRange(t *testing.T) {
+	var existing = []pgPartition{{
+		Name: `http_log_p20260118`,
+		From: time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		Name: `http_log_p20260120`,
+		From: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC),
+	}}
+
+	// The logs older than the existing partitions, and in the gap
+	// between partitions.
+	var (
+		from = time.Date(2026, 1, 17, 23, 0, 0, 0, time.UTC)
+		to   = time.Date(2026, 1, 20, 5, 0, 0, 0, time.UTC)
+		exp  = []pgPartition{{
+			Name: `http_log_p20260117`,
+			From: time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260119`,
+			From: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+		}}
+	)
+
+	var got = partitionsInRange(from, to, partitionByDay, existing)
+	test.Assert(t, `out of range`, exp, got)
+
+	// The logs within the existing partition.
+	from = time.Date(2026, 1, 18, 1, 0, 0, 0, time.UTC)
+	to = time.Date(2026, 1, 18, 2, 0, 0, 0, time.UTC)
+
+	got = partitionsInRange(from, to, partitionByDay, existing)
+	test.Assert(t, `in range`, 0, len(got))
+}
+
+func TestPartitionsInRetention(t *testing.T) {
+	var (
+		cutoff = time.Date(2026, 1, 19, 10, 0, 0, 0, time.UTC)
+		list   = []pgPartition{{
+			Name: `http_log_p20260117`,
+			From: time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260118`,
+			From: time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260119`,
+			From: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+		}}
+	)
+
+	var got = partitionsInRetention(cutoff, list)
+	test.Assert(t, `with cutoff`, list[2:], got)
+
+	got = partitionsInRetention(time.Time{}, list)
+	test.Assert(t, `without cutoff`, list, got)
+}
+
+func TestLogsInPartitions(t *testing.T) {
+	var (
+		partitions = []pgPartition{{
+			Name: partitionNameLegacy,
+			To:   time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+		}, {
+			Name: `http_log_p20260119`,
+			From: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+		}}
+		listLog = []*HTTPLog{{
+			RequestDate: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+		}, {
+			// The partition is expired and not created.
+			RequestDate: time.Date(2026, 1, 18, 5, 0, 0, 0, time.UTC),
+		}, {
+			RequestDate: time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+		}, {
+			RequestDate: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC),
+		}}
+	)
+
+	var got = logsInPartitions(listLog, partitions)
+
+	test.Assert(t, `logsInPartitions`, []*HTTPLog{listLog[0], listLog[2]}, got)
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"fmt"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
+)
+
+// viewNameHTTPLogBackend1m the name of TimescaleDB continuous aggregate
+// that contains the per-minute statistics for each backend.
+const viewNameHTTPLogBackend1m = `http_log_backend_1m`
+
+// viewColumnTimeAllSum the column in the continuous aggregate that
+// does not exist in the view created by the previous version.
+// The view that does not have this column is recreated.
+const viewColumnTimeAllSum = `time_all_sum`
+
+// setupTimescaledb convert the http_log table into hypertable and setup
+// the compression, retention, and continuous aggregate policies.
+//
+// All statements are idempotent, so it is safe to be executed on each
+// start.
+// The compression settings is altered only if its different, since
+// TimescaleDB reject it once the hypertable has compressed chunks.
+func (fw *forwarderPostgresql) setupTimescaledb() (err error) {
+	var (
+		logp  = `setupTimescaledb`
+		listq = timescaledbStatements(fw.cfg)
+
+		q         string
+		ncol      int
+		isNewView bool
+	)
+
+	q = fmt.Sprintf(`SELECT count(*) FROM information_schema.columns`+
+		` WHERE table_name = '%s' AND column_name = '%s';`,
+		viewNameHTTPLogBackend1m, viewColumnTimeAllSum)
+
+	err = fw.conn.QueryRow(q).Scan(&ncol)
+	if err != nil {
+		return fmt.Errorf(`%s: %q: %w`, logp, q, err)
+	}
+
+	// The continuous aggregate that is not exist or outdated will
+	// be created with no data, so its need to be refreshed.
+	isNewView = ncol == 0
+
+	for _, q = range listq {
+		_, err = fw.conn.Exec(q)
+		if err != nil {
+			return fmt.Errorf(`%s: %q: %w`, logp, q, err)
+		}
+	}
+
+	if !isNewView {
+		return nil
+	}
+
+	mlog.Outf(`%s: refreshing continuous aggregate %s`, logp,
+		viewNameHTTPLogBackend1m)
+
+	q = fmt.Sprintf(`CALL refresh_continuous_aggregate('%s', NULL, NULL);`,
+		viewNameHTTPLogBackend1m)
+
+	_, err = fw.conn.Exec(q)
+	if err != nil {
+		return fmt.Errorf(`%s: %q: %w`, logp, q, err)
+	}
+	return nil
+}
+
+// timescaledbStatements generate list of SQL statements to setup the
+// TimescaleDB based on the forwarder configuration.
+func timescaledbStatements(cfg ConfigForwarder) (listq []string) {
+	listq = append(listq,
+		`CREATE EXTENSION IF NOT EXISTS timescaledb;`,
+
+		fmt.Sprintf(`SELECT create_hypertable('%s', 'request_date',`+
+			` chunk_time_interval => INTERVAL '%s',`+
+			` if_not_exists => TRUE, migrate_data => TRUE);`,
+			tableNameHTTPLog, cfg.chunkInterval),
+	)
+
+	if len(cfg.compressAfter) != 0 {
+		listq = append(listq,
+			fmt.Sprintf(`DO $$ BEGIN`+
+				` IF NOT EXISTS (SELECT 1`+
+				` FROM timescaledb_information.compression_settings`+
+				` WHERE hypertable_name = '%[1]s'`+
+				` AND attname = 'backend_name'`+
+				` AND segmentby_column_index = 1)`+
+				` OR NOT EXISTS (SELECT 1`+
+				` FROM timescaledb_information.compression_settings`+
+				` WHERE hypertable_name = '%[1]s'`+
+				` AND attname = 'request_date'`+
+				` AND orderby_column_index = 1 AND NOT orderby_asc)`+
+				` THEN ALTER TABLE %[1]s SET (timescaledb.compress,`+
+				` timescaledb.compress_segmentby = 'backend_name',`+
+				` timescaledb.compress_orderby = 'request_date DESC');`+
+				` END IF; END $$;`,
+				tableNameHTTPLog),
+		)
+	}
+
+	// The policies is removed first so any changes on configuration
+	// will be applied.
+	listq = append(listq,
+		fmt.Sprintf(`SELECT remove_compression_policy('%s', if_exists => TRUE);`,
+			tableNameHTTPLog),
+	)
+	if len(cfg.compressAfter) != 0 {
+		listq = append(listq,
+			fmt.Sprintf(`SELECT add_compression_policy('%s', INTERVAL '%s');`,
+				tableNameHTTPLog, cfg.compressAfter),
+		)
+	}
+
+	listq = append(listq,
+		fmt.Sprintf(`SELECT remove_retention_policy('%s', if_exists => TRUE);`,
+			tableNameHTTPLog),
+	)
+	if len(cfg.retention) != 0 {
+		listq = append(listq,
+			fmt.Sprintf(`SELECT add_retention_policy('%s', INTERVAL '%s');`,
+				tableNameHTTPLog, cfg.retention),
+		)
+	}
+
+	// The continuous aggregate created by the previous version does
+	// not weight the statistics by the sample_weight, so its dropped
+	// and recreated.
+	// The time_all_sum can be divided by requests to get the average
+	// time_all on the larger bucket.
+	listq = append(listq,
+		fmt.Sprintf(`DO $$ BEGIN IF EXISTS (SELECT 1`+
+			` FROM timescaledb_information.continuous_aggregates`+
+			` WHERE view_name = '%[1]s')`+
+			` AND NOT EXISTS (SELECT 1 FROM information_schema.columns`+
+			` WHERE table_name = '%[1]s' AND column_name = '%[2]s')`+
+			` THEN DROP MATERIALIZED VIEW %[1]s;`+
+			` END IF; END $$;`,
+			viewNameHTTPLogBackend1m, viewColumnTimeAllSum),
+
+		fmt.Sprintf(`CREATE MATERIALIZED VIEW IF NOT EXISTS %s`+
+			` WITH (timescaledb.continuous) AS`+
+			` SELECT time_bucket(INTERVAL '1 minute', request_date) AS bucket`+
+			`, backend_name`+
+			`, sum(sample_weight) AS requests`+
+			`, sum(CASE WHEN status_code BETWEEN 200 AND 299 THEN sample_weight ELSE 0 END) AS status_2xx`+
+			`, sum(CASE WHEN status_code BETWEEN 300 AND 399 THEN sample_weight ELSE 0 END) AS status_3xx`+
+			`, sum(CASE WHEN status_code BETWEEN 400 AND 499 THEN sample_weight ELSE 0 END) AS status_4xx`+
+			`, sum(CASE WHEN status_code >= 500 THEN sample_weight ELSE 0 END) AS status_5xx`+
+			`, sum(time_all * sample_weight) / nullif(sum(sample_weight), 0) AS time_all_avg`+
+			`, sum(time_all * sample_weight) AS %s`+
+			`, max(time_all) AS time_all_max`+
+			`, sum(bytes_read * sample_weight) AS bytes_read`+
+			` FROM %s`+
+			` GROUP BY bucket, backend_name`+
+			` WITH NO DATA;`,
+			viewNameHTTPLogBackend1m, viewColumnTimeAllSum,
+			tableNameHTTPLog),
+
+		fmt.Sprintf(`SELECT add_continuous_aggregate_policy('%s',`+
+			` start_offset => INTERVAL '1 hour',`+
+			` end_offset => INTERVAL '1 minute',`+
+			` schedule_interval => INTERVAL '1 minute',`+
+			` if_not_exists => TRUE);`,
+			viewNameHTTPLogBackend1m),
+	)
+
+	return listq
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"strings"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestTimescaledbStatements(t *testing.T) {
+	type testCase struct {
+		tag string
+		cfg ConfigForwarder
+	}
+
+	var (
+		logp = `TestTimescaledbStatements`
+
+		tdata *test.Data
+		err   error
+	)
+
+	tdata, err = test.LoadData(`testdata/timescaledbStatements_test.txt`)
+	if err != nil {
+		t.Fatal(logp, err)
+	}
+
+	var cases = []testCase{{
+		tag: `default`,
+		cfg: ConfigForwarder{},
+	}, {
+		tag: `with_policies`,
+		cfg: ConfigForwarder{
+			ChunkInterval: `12h`,
+			CompressAfter: `7d`,
+			Retention:     `3M`,
+		},
+	}}
+
+	var (
+		c   testCase
+		got string
+	)
+	for _, c = range cases {
+		err = c.cfg.initPostgresql()
+		if err != nil {
+			t.Fatal(logp, err)
+		}
+
+		got = strings.Join(timescaledbStatements(c.cfg), "\n") + "\n"
+
+		test.Assert(t, c.tag, string(tdata.Output[c.tag]), got)
+	}
+}
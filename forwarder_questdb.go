@@ -6,9 +6,13 @@ package haminer
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -17,25 +21,126 @@ import (
 
 const (
 	defQuestdbPort = 9009
+
+	defQuestdbTimeout = 10 * time.Second
 )
 
+// questdbCreateTable define the DDL to create the table in Questdb.
+// The tags with unbounded cardinality, like http_url and client_ip, are
+// stored as VARCHAR instead of SYMBOL.
+// The fields are stored as DOUBLE, since the ILP write the integer without
+// "i" suffix.
+const questdbCreateTable = `CREATE TABLE IF NOT EXISTS ` + influxdMeasurement + ` (
+  host          SYMBOL
+, server        SYMBOL
+, backend       SYMBOL
+, frontend      SYMBOL
+, http_method   SYMBOL
+, http_url      VARCHAR
+, http_query    VARCHAR
+, http_proto    SYMBOL
+, http_status   SYMBOL
+, term_state    SYMBOL
+, client_ip     VARCHAR
+, client_port   VARCHAR
+, client_label  SYMBOL
+, client_host   VARCHAR
+, geo_country   SYMBOL
+, geo_city      SYMBOL
+, asn           SYMBOL
+, as_org        SYMBOL
+, ua_browser    SYMBOL
+, ua_os         SYMBOL
+, ua_device     SYMBOL
+, is_bot        SYMBOL
+, is_error      SYMBOL
+, time_req      DOUBLE
+, time_wait     DOUBLE
+, time_connect  DOUBLE
+, time_rsp      DOUBLE
+, time_all      DOUBLE
+, conn_active   DOUBLE
+, conn_frontend DOUBLE
+, conn_backend  DOUBLE
+, conn_server   DOUBLE
+, conn_retries  DOUBLE
+, queue_server  DOUBLE
+, queue_backend DOUBLE
+, bytes_read    DOUBLE
+, sample_weight DOUBLE
+, request_date  TIMESTAMP
+) TIMESTAMP(request_date) PARTITION BY %s%s;`
+
 // forwarderQuestdb client for questdb.
+//
+// The logs can be written using raw UDP or TCP to ILP port, or using ILP
+// over HTTP if the URL scheme is "http" or "https".
 type forwarderQuestdb struct {
-	conn net.Conn
-	buf  bytes.Buffer
+	conn    net.Conn
+	httpc   *http.Client
+	cfg     *ConfigForwarder
+	address string
+	buf     bytes.Buffer
 }
 
 // newForwarderQuestdb create and initialize client connection using the URL in
-// the ConfigForwarder.
+// the ConfigForwarder, and create the table if the "/exec" API is set.
 func newForwarderQuestdb(cfg *ConfigForwarder) (questc *forwarderQuestdb, err error) {
+	var logp = `newForwarderQuestdb`
+
+	questc, err = openForwarderQuestdb(cfg)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	// The failed DDL is not fatal, the ILP create the table on the first
+	// write, using the default schema.
+	if questc != nil && len(cfg.apiExec) != 0 {
+		err = questc.createTable()
+		if err != nil {
+			log.Printf(`%s: %s`, logp, err)
+		}
+	}
+	return questc, nil
+}
+
+// openForwarderQuestdb create and initialize client connection using the
+// URL in the ConfigForwarder, without creating the table.
+func openForwarderQuestdb(cfg *ConfigForwarder) (questc *forwarderQuestdb, err error) {
 	if cfg == nil || len(cfg.URL) == 0 {
 		return nil, nil
 	}
 
-	var (
-		logp    = `newForwarderQuestdb`
-		timeout = 10 * time.Second
+	var logp = `openForwarderQuestdb`
 
+	if len(cfg.scheme) == 0 {
+		err = cfg.initQuestdb()
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
+		}
+	}
+
+	questc = &forwarderQuestdb{
+		cfg: cfg,
+	}
+
+	if questc.isHTTP() || len(cfg.apiExec) != 0 {
+		var tr = &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec
+			},
+		}
+		questc.httpc = &http.Client{
+			Transport: tr,
+			Timeout:   defQuestdbTimeout,
+		}
+	}
+
+	if questc.isHTTP() {
+		return questc, nil
+	}
+
+	var (
 		surl    *url.URL
 		address string
 		ip      net.IP
@@ -47,22 +152,18 @@ func newForwarderQuestdb(cfg *ConfigForwarder) (questc *forwarderQuestdb, err er
 		return nil, fmt.Errorf(`%s: %w`, logp, err)
 	}
 
-	if len(surl.Scheme) == 0 {
-		surl.Scheme = "udp"
-	}
-
 	address, ip, port = libnet.ParseIPPort(surl.Host, defQuestdbPort)
 	if len(address) == 0 {
-		address = fmt.Sprintf(`%s:%d`, ip, port)
+		questc.address = fmt.Sprintf(`%s:%d`, ip, port)
 	} else {
-		address = fmt.Sprintf(`%s:%d`, address, port)
+		questc.address = fmt.Sprintf(`%s:%d`, address, port)
 	}
 
-	questc = &forwarderQuestdb{}
-
-	questc.conn, err = net.DialTimeout(surl.Scheme, address, timeout)
+	// The failed dial is not fatal, the write will re-dial, so the
+	// forwarder recover once the questdb is up.
+	err = questc.dial()
 	if err != nil {
-		return nil, fmt.Errorf(`%s: %w`, logp, err)
+		log.Printf(`%s: %s`, logp, err)
 	}
 
 	return questc, nil
@@ -71,35 +172,252 @@ func newForwarderQuestdb(cfg *ConfigForwarder) (questc *forwarderQuestdb, err er
 // Forwards implement the Forwarder interface.
 // It will write all logs to questdb.
 func (questc *forwarderQuestdb) Forwards(logs []*HTTPLog) {
-	var (
-		logp = `forwarderQuestdb: Forwards`
-		now  = time.Now()
+	var err = questc.forward(logs)
+	if err != nil {
+		log.Printf(`forwarderQuestdb: Forwards: %s`, err)
+	}
+}
 
-		httpLog *HTTPLog
-		data    []byte
-		err     error
-	)
+// forward write all logs to Questdb and return the error if any.
+func (questc *forwarderQuestdb) forward(logs []*HTTPLog) (err error) {
+	var httpLog *HTTPLog
 
 	questc.buf.Reset()
 
 	for _, httpLog = range logs {
 		err = httpLog.writeIlp(&questc.buf)
 		if err != nil {
-			log.Printf(`%s: %s`, logp, err)
-			return
+			return err
 		}
 	}
 
-	data = questc.buf.Bytes()
+	if questc.isHTTP() {
+		return questc.post(questc.buf.Bytes())
+	}
+	return questc.write(questc.buf.Bytes())
+}
+
+func (questc *forwarderQuestdb) isHTTP() bool {
+	return questc.cfg.scheme == questdbSchemeHTTP ||
+		questc.cfg.scheme == questdbSchemeHTTPS
+}
+
+// dial open new connection to the ILP port.
+func (questc *forwarderQuestdb) dial() (err error) {
+	questc.conn, err = net.DialTimeout(questc.cfg.scheme, questc.address,
+		defQuestdbTimeout)
+	if err != nil {
+		questc.conn = nil
+		return err
+	}
+	return nil
+}
+
+// write the data into ILP port.
+// For TCP, if the previous connection has been closed by server, it will
+// re-connect and write only the lines that has not been written to the
+// previous connection.
+//
+// The lines that has been written to the previous connection, but not yet
+// received by the server when the connection reset, are lost.
+// In other words, the delivery is at-most-once, the same line is never
+// written twice.
+func (questc *forwarderQuestdb) write(data []byte) (err error) {
+	var logp = `write`
+
+	if questc.conn == nil {
+		err = questc.dial()
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+	}
+
+	var n int
+
+	n, err = questc.writeConn(data)
+	if err == nil {
+		return nil
+	}
+	if questc.cfg.scheme != questdbSchemeTCP {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	log.Printf(`%s: %s, reconnecting to %s`, logp, err, questc.address)
+
+	_ = questc.conn.Close()
+
+	err = questc.dial()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	data = unwrittenLines(data, n)
+	if len(data) == 0 {
+		return nil
+	}
+
+	_, err = questc.writeConn(data)
+	if err != nil {
+		_ = questc.conn.Close()
+		questc.conn = nil
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return nil
+}
+
+// unwrittenLines return the lines in data that has not been written
+// completely, where n is the number of bytes that has been written.
+// The partially written line is returned in full, since the server
+// discard the incomplete line when the connection closed.
+func unwrittenLines(data []byte, n int) []byte {
+	var x = bytes.LastIndexByte(data[:n], '\n')
+	return data[x+1:]
+}
+
+func (questc *forwarderQuestdb) writeConn(data []byte) (n int, err error) {
+	var now = time.Now()
 
 	err = questc.conn.SetWriteDeadline(now.Add(5 * time.Second))
 	if err != nil {
-		log.Printf(`%s: SetWriteDeadline: %s`, logp, err)
-		return
+		return 0, fmt.Errorf(`SetWriteDeadline: %w`, err)
+	}
+
+	n, err = questc.conn.Write(data)
+	if err != nil {
+		return n, fmt.Errorf(`Write: %w`, err)
+	}
+	return n, nil
+}
+
+// post the data using ILP over HTTP.
+// Questdb response with 204 if all lines has been written, otherwise it
+// will return JSON with error message.
+func (questc *forwarderQuestdb) post(data []byte) (err error) {
+	var (
+		logp = `post`
+		ctx  = context.Background()
+
+		httpReq *http.Request
+		httpRes *http.Response
+	)
+
+	httpReq, err = http.NewRequestWithContext(ctx, http.MethodPost,
+		questc.cfg.apiWrite, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	httpReq.Header.Set(`Content-Type`, `text/plain; charset=utf-8`)
+	if len(questc.cfg.headerToken) != 0 {
+		httpReq.Header.Set(`Authorization`, questc.cfg.headerToken)
+	}
+
+	httpRes, err = questc.httpc.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	var rspBody []byte
+
+	rspBody, err = io.ReadAll(httpRes.Body)
+	_ = httpRes.Body.Close()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	if httpRes.StatusCode >= 200 && httpRes.StatusCode <= 299 {
+		return nil
+	}
+
+	return fmt.Errorf(`%s: response: %d %s`, logp, httpRes.StatusCode,
+		bytes.TrimSpace(rspBody))
+}
+
+// createTable create the table using Questdb HTTP API "/exec", if its not
+// exist.
+// If TTL is set, the TTL of existing table will be updated.
+func (questc *forwarderQuestdb) createTable() (err error) {
+	var (
+		logp = `createTable`
+		ttl  string
+	)
+
+	if len(questc.cfg.ttl) != 0 {
+		ttl = ` TTL ` + questc.cfg.ttl
+	}
+
+	var ddl = fmt.Sprintf(questdbCreateTable, questc.cfg.PartitionBy, ttl)
+
+	err = questc.exec(ddl)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
 	}
 
-	_, err = questc.conn.Write(data)
+	if len(ttl) == 0 {
+		return nil
+	}
+
+	ddl = fmt.Sprintf(`ALTER TABLE %s SET%s;`, influxdMeasurement, ttl)
+
+	err = questc.exec(ddl)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return nil
+}
+
+// exec execute the SQL query using HTTP API "/exec".
+func (questc *forwarderQuestdb) exec(query string) (err error) {
+	var (
+		logp = `exec`
+		ctx  = context.Background()
+		q    = url.Values{}
+
+		httpReq *http.Request
+		httpRes *http.Response
+	)
+
+	q.Set(`query`, query)
+
+	httpReq, err = http.NewRequestWithContext(ctx, http.MethodGet,
+		questc.cfg.apiExec+`?`+q.Encode(), nil)
 	if err != nil {
-		log.Printf(`%s: Write: %s`, logp, err)
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	if len(questc.cfg.headerToken) != 0 {
+		httpReq.Header.Set(`Authorization`, questc.cfg.headerToken)
+	}
+
+	httpRes, err = questc.httpc.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	var rspBody []byte
+
+	rspBody, err = io.ReadAll(httpRes.Body)
+	_ = httpRes.Body.Close()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	if httpRes.StatusCode >= 200 && httpRes.StatusCode <= 299 {
+		return nil
+	}
+
+	return fmt.Errorf(`%s: response: %d %s`, logp, httpRes.StatusCode,
+		bytes.TrimSpace(rspBody))
+}
+
+// close the connection to Questdb.
+func (questc *forwarderQuestdb) close() (err error) {
+	if questc.httpc != nil {
+		questc.httpc.CloseIdleConnections()
+	}
+	if questc.conn != nil {
+		err = questc.conn.Close()
+		questc.conn = nil
 	}
+	return err
 }
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestForwarderQuestdb_post(t *testing.T) {
+	type testCase struct {
+		desc      string
+		token     string
+		expAuth   string
+		expStatus int
+	}
+
+	var (
+		gotAuth string
+		gotPath string
+		gotBody []byte
+		status  int
+	)
+
+	var srv = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == `/exec` {
+			res.WriteHeader(http.StatusOK)
+			return
+		}
+		gotAuth = req.Header.Get(`Authorization`)
+		gotPath = req.URL.String()
+		gotBody, _ = io.ReadAll(req.Body)
+		res.WriteHeader(status)
+		if status != http.StatusNoContent {
+			_, _ = res.Write([]byte(`{"code":"invalid","message":"failed"}`))
+		}
+	}))
+	defer srv.Close()
+
+	var cases = []testCase{{
+		desc:      `With token`,
+		token:     `secret`,
+		expAuth:   `Bearer secret`,
+		expStatus: http.StatusNoContent,
+	}, {
+		desc:      `With bad request`,
+		expStatus: http.StatusBadRequest,
+	}}
+
+	var (
+		httpLog = &HTTPLog{
+			RequestDate:      time.Date(2024, 3, 17, 5, 8, 28, 0, time.UTC),
+			ServerName:       `be-http2`,
+			BackendName:      `be-http`,
+			FrontendName:     `fe-http`,
+			HTTPMethod:       `GET`,
+			HTTPURL:          `/`,
+			HTTPProto:        `HTTP/1.1`,
+			TerminationState: `----`,
+			StatusCode:       200,
+		}
+
+		c      testCase
+		questc *forwarderQuestdb
+		err    error
+	)
+
+	_hostname = `localhost`
+
+	for _, c = range cases {
+		t.Log(c.desc)
+
+		var cfg = &ConfigForwarder{
+			URL:   srv.URL,
+			Token: c.token,
+		}
+
+		questc, err = newForwarderQuestdb(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		status = c.expStatus
+		_ = httpLog.writeIlp(&questc.buf)
+
+		err = questc.post(questc.buf.Bytes())
+		if c.expStatus == http.StatusNoContent {
+			if err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			test.Assert(t, `error`,
+				`post: response: 400 {"code":"invalid","message":"failed"}`,
+				err.Error())
+		}
+
+		test.Assert(t, `Authorization`, c.expAuth, gotAuth)
+		test.Assert(t, `path`, `/write?precision=n`, gotPath)
+		test.Assert(t, `body`, questc.buf.String(), string(gotBody))
+
+		questc.buf.Reset()
+	}
+}
+
+func TestForwarderQuestdb_createTable(t *testing.T) {
+	var gotQuery []string
+
+	var srv = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		gotQuery = append(gotQuery, req.URL.Query().Get(`query`))
+		res.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var cfg = &ConfigForwarder{
+		URL:         `udp://127.0.0.1:9009`,
+		ExecURL:     srv.URL,
+		PartitionBy: `month`,
+		TTL:         `12M`,
+	}
+
+	var err = cfg.initQuestdb()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = newForwarderQuestdb(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var exp = []string{
+		fmt.Sprintf(questdbCreateTable, `MONTH`, ` TTL 12 MONTHS`),
+		`ALTER TABLE haproxy SET TTL 12 MONTHS;`,
+	}
+
+	test.Assert(t, `query`, exp, gotQuery)
+}
+
+func TestNewForwarderQuestdb_dialFailed(t *testing.T) {
+	var ln, err = net.Listen(`tcp`, `127.0.0.1:0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var address = ln.Addr().String()
+	_ = ln.Close()
+
+	var cfg = &ConfigForwarder{
+		URL: `tcp://` + address,
+	}
+
+	var questc *forwarderQuestdb
+
+	questc, err = newForwarderQuestdb(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `conn`, nil, questc.conn)
+
+	// The questdb is up, the write should re-dial.
+	ln, err = net.Listen(`tcp`, address)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+
+	var gotq = make(chan []byte, 1)
+	go func() {
+		var conn, errAccept = ln.Accept()
+		if errAccept != nil {
+			return
+		}
+		defer conn.Close()
+		var body, _ = io.ReadAll(conn)
+		gotq <- body
+	}()
+
+	err = questc.write([]byte("haproxy x=1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = questc.close()
+
+	test.Assert(t, `body`, "haproxy x=1\n", string(<-gotq))
+}
+
+func TestUnwrittenLines(t *testing.T) {
+	type testCase struct {
+		desc string
+		exp  string
+		n    int
+	}
+
+	var (
+		data  = []byte("haproxy x=1\nhaproxy x=2\nhaproxy x=3\n")
+		cases = []testCase{{
+			desc: `Nothing written`,
+			exp:  string(data),
+		}, {
+			desc: `Partial first line`,
+			n:    5,
+			exp:  string(data),
+		}, {
+			desc: `Full first line`,
+			n:    12,
+			exp:  "haproxy x=2\nhaproxy x=3\n",
+		}, {
+			desc: `Partial second line`,
+			n:    20,
+			exp:  "haproxy x=2\nhaproxy x=3\n",
+		}, {
+			desc: `All written`,
+			n:    len(data),
+			exp:  ``,
+		}}
+
+		c testCase
+	)
+	for _, c = range cases {
+		test.Assert(t, c.desc, c.exp, string(unwrittenLines(data, c.n)))
+	}
+}
+
+func TestNewForwarderQuestdb_createTableFailed(t *testing.T) {
+	var srv = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+		res.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var cfg = &ConfigForwarder{
+		URL:     `udp://127.0.0.1:9009`,
+		ExecURL: srv.URL,
+		TTL:     `12M`,
+	}
+
+	var questc, err = newForwarderQuestdb(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer questc.close()
+
+	test.Assert(t, `questc is not nil`, true, questc != nil)
+}
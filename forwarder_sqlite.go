@@ -0,0 +1,325 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
+	libsql "git.sr.ht/~shulhan/pakakeh.go/lib/sql"
+
+	// Register the SQLite driver.
+	_ "modernc.org/sqlite"
+)
+
+// driverNameSqlite the name of SQLite driver registered by
+// modernc.org/sqlite.
+const driverNameSqlite = `sqlite`
+
+// dirMigrationSqlite the directory in memfsDatabase that contains the
+// migration files for SQLite.
+const dirMigrationSqlite = `/sqlite`
+
+// forwarderSqlite the client to write logs to SQLite database file.
+type forwarderSqlite struct {
+	conn *libsql.Client
+	cfg  ConfigForwarder
+}
+
+// newForwarderSqlite open the SQLite database file in the URL and apply
+// the pending migrations.
+func newForwarderSqlite(cfg ConfigForwarder) (fw *forwarderSqlite, err error) {
+	var logp = `newForwarderSqlite`
+
+	fw, err = openForwarderSqlite(cfg)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = fw.migrate()
+	if err != nil {
+		_ = fw.conn.Close()
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return fw, nil
+}
+
+// openForwarderSqlite open the SQLite database file in the URL, without
+// migrating the schema.
+func openForwarderSqlite(cfg ConfigForwarder) (fw *forwarderSqlite, err error) {
+	var logp = `openForwarderSqlite`
+
+	fw = &forwarderSqlite{
+		cfg: cfg,
+	}
+
+	var opts = libsql.ClientOptions{
+		DriverName: driverNameSqlite,
+		DSN:        sqliteDSN(cfg.URL),
+	}
+
+	fw.conn, err = libsql.NewClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return fw, nil
+}
+
+// lastMigration return the last file name that has been applied to the
+// database, or empty string if no migration has been applied.
+func (fw *forwarderSqlite) lastMigration() (lastFile string, err error) {
+	var (
+		logp = `lastMigration`
+		n    int
+	)
+
+	err = fw.conn.QueryRow(`SELECT count(*) FROM sqlite_master`+
+		` WHERE type = 'table' AND name = ?;`,
+		tableNameMigration).Scan(&n)
+	if err != nil {
+		return ``, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	if n == 0 {
+		return ``, nil
+	}
+
+	var q = `SELECT filename FROM ` + tableNameMigration +
+		` ORDER BY filename DESC LIMIT 1;`
+
+	err = fw.conn.QueryRow(q).Scan(&lastFile)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ``, nil
+		}
+		return ``, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return lastFile, nil
+}
+
+// pendingMigration return list of migration files that will be applied.
+func (fw *forwarderSqlite) pendingMigration() (pending []migrationFile, err error) {
+	var (
+		logp = `pendingMigration`
+
+		list     []migrationFile
+		lastFile string
+	)
+
+	list, err = listMigrationFile(memfsDatabase, dirMigrationSqlite)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	lastFile, err = fw.lastMigration()
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	pending, err = pendingMigration(lastFile, list)
+	if err != nil {
+		return nil, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return pending, nil
+}
+
+// migrate apply the pending migration files, each in its own
+// transaction, and record it in the migration table.
+//
+// It will return [ErrSchemaNewer] if the database has been migrated by
+// newer version of haminer.
+func (fw *forwarderSqlite) migrate() (err error) {
+	var (
+		logp = `migrate`
+
+		pending []migrationFile
+	)
+
+	pending, err = fw.pendingMigration()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	_, err = fw.conn.Exec(`CREATE TABLE IF NOT EXISTS ` + tableNameMigration + ` (
+  filename   TEXT PRIMARY KEY
+, applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);`)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	var mfile migrationFile
+	for _, mfile = range pending {
+		err = fw.migrateApply(mfile)
+		if err != nil {
+			return fmt.Errorf(`%s: %q: %w`, logp, mfile.name, err)
+		}
+	}
+	return nil
+}
+
+// migrateApply execute the content of mfile and record its name in the
+// migration table in single transaction.
+func (fw *forwarderSqlite) migrateApply(mfile migrationFile) (err error) {
+	var sqltx *sql.Tx
+
+	sqltx, err = fw.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = sqltx.Exec(string(mfile.content))
+	if err != nil {
+		_ = sqltx.Rollback()
+		return err
+	}
+
+	_, err = sqltx.Exec(`INSERT INTO `+tableNameMigration+
+		` (filename) VALUES (?);`, mfile.name)
+	if err != nil {
+		_ = sqltx.Rollback()
+		return err
+	}
+	return sqltx.Commit()
+}
+
+// checkSchema return an error if the database schema has pending
+// migrations.
+// Unlike migrate, it does not modify the database.
+func (fw *forwarderSqlite) checkSchema() (err error) {
+	var (
+		logp = `checkSchema`
+
+		pending []migrationFile
+	)
+
+	pending, err = fw.pendingMigration()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	if len(pending) != 0 {
+		return fmt.Errorf(`%s: %d pending migration(s) from %s,`+
+			` run "haminer migrate" first`,
+			logp, len(pending), pending[0].name)
+	}
+	return nil
+}
+
+// migrateSqlite run the migration on the sqlite forwarder.
+func migrateSqlite(fwCfg ConfigForwarder, dryRun bool, out io.Writer) (err error) {
+	var (
+		logp = `migrateSqlite`
+
+		fw *forwarderSqlite
+	)
+
+	fw, err = openForwarderSqlite(fwCfg)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	defer fw.close()
+
+	if !dryRun {
+		err = fw.migrate()
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+		fmt.Fprintln(out, `-- sqlite: migration completed`)
+		return nil
+	}
+
+	var pending []migrationFile
+
+	pending, err = fw.pendingMigration()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	printMigration(out, pending)
+	return nil
+}
+
+// sqliteDSN return the data source name for the file path.
+// The journal is set to WAL, so the search does not block the writes,
+// and each connection wait up to five seconds when the database is
+// locked.
+func sqliteDSN(path string) string {
+	var dsn = path
+	if !strings.HasPrefix(dsn, `file:`) {
+		dsn = `file:` + dsn
+	}
+	var sep = `?`
+	if strings.Contains(dsn, `?`) {
+		sep = `&`
+	}
+	return dsn + sep + `_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)`
+}
+
+// Forwards insert the list of HTTP log into the SQLite.
+func (fw *forwarderSqlite) Forwards(listLog []*HTTPLog) {
+	var err = fw.forward(listLog)
+	if err != nil {
+		mlog.Errf(`forwarderSqlite: Forwards: %s`, err)
+	}
+}
+
+// forward insert all logs into table http_log in single transaction and
+// return the error if any.
+// The request_date is stored in UTC, so the dates can be compared as
+// text.
+func (fw *forwarderSqlite) forward(listLog []*HTTPLog) (err error) {
+	var sqltx *sql.Tx
+
+	sqltx, err = fw.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	var (
+		httpLog = HTTPLog{}
+		meta    = httpLog.generateSQLMeta(driverNameSqlite, libsql.DMLKindInsert)
+		q       = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s);`,
+			tableNameHTTPLog, meta.Names(), meta.Holders())
+
+		stmt *sql.Stmt
+		alog *HTTPLog
+	)
+
+	stmt, err = sqltx.Prepare(q)
+	if err != nil {
+		_ = sqltx.Rollback()
+		return err
+	}
+
+	for _, alog = range listLog {
+		httpLog = *alog
+		httpLog.RequestDate = httpLog.RequestDate.UTC()
+
+		_, err = stmt.Exec(meta.ListValue...)
+		if err != nil {
+			_ = stmt.Close()
+			_ = sqltx.Rollback()
+			return err
+		}
+	}
+
+	err = stmt.Close()
+	if err != nil {
+		_ = sqltx.Rollback()
+		return err
+	}
+	return sqltx.Commit()
+}
+
+// close the database connection.
+func (fw *forwarderSqlite) close() (err error) {
+	return fw.conn.Close()
+}
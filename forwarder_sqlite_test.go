@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestForwarderSqlite_migrate(t *testing.T) {
+	var (
+		fwCfg = ConfigForwarder{
+			URL: filepath.Join(t.TempDir(), `haminer.db`),
+		}
+		cfg = &Config{
+			Forwarders: map[string]*ConfigForwarder{
+				forwarderKindSqlite: &fwCfg,
+			},
+		}
+
+		out bytes.Buffer
+	)
+
+	var err = Migrate(cfg, true, false, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `dry-run`, true,
+		bytes.HasPrefix(out.Bytes(), []byte("-- 0001_http_log.sql\n")))
+
+	var fw *forwarderSqlite
+
+	fw, err = openForwarderSqlite(fwCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.close()
+
+	var lastFile string
+
+	lastFile, err = fw.lastMigration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `lastMigration before migrate`, ``, lastFile)
+
+	// Migrating twice does not apply the same file again.
+	err = fw.migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fw.migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list []migrationFile
+
+	list, err = listMigrationFile(memfsDatabase, dirMigrationSqlite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastFile, err = fw.lastMigration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `lastMigration`, list[len(list)-1].name, lastFile)
+
+	var pending []migrationFile
+
+	pending, err = fw.pendingMigration()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `pending`, 0, len(pending))
+
+	out.Reset()
+	err = Migrate(cfg, true, false, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `dry-run`, "-- no pending migration\n", out.String())
+
+	// The database migrated by newer version.
+	_, err = fw.conn.Exec(`INSERT INTO _migration (filename) VALUES ('9999_newer.sql');`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fw.checkSchema()
+	test.Assert(t, `ErrSchemaNewer`, true, errors.Is(err, ErrSchemaNewer))
+}
+
+func TestForwarderSqlite_search(t *testing.T) {
+	var cfg = ConfigForwarder{
+		URL: filepath.Join(t.TempDir(), `haminer.db`),
+	}
+
+	var fw, err = newForwarderSqlite(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.close()
+
+	var (
+		date = time.Date(2026, 1, 18, 5, 8, 28, 0, time.UTC)
+		wib  = time.FixedZone(`WIB`, 7*3600)
+
+		listLog = []*HTTPLog{{
+			RequestDate:    date.Add(-time.Minute).In(wib),
+			BackendName:    `api`,
+			HTTPURL:        `/V1/users`,
+			StatusCode:     200,
+			HeaderRequest:  map[string]string{`host`: `api.local`},
+			IsBot:          true,
+			SampleWeight:   1,
+			CookieRequest:  `-`,
+			CookieResponse: `-`,
+		}, {
+			RequestDate:  date,
+			BackendName:  `api`,
+			HTTPURL:      `/v1/users`,
+			StatusCode:   500,
+			SampleWeight: 2,
+		}, {
+			RequestDate:  date,
+			BackendName:  `web`,
+			HTTPURL:      `/v1/users`,
+			StatusCode:   503,
+			SampleWeight: 1,
+		}}
+	)
+
+	err = fw.forward(listLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The search order is served by the index, without sorting.
+	var (
+		plan   string
+		detail string
+		rows   *sql.Rows
+	)
+
+	rows, err = fw.conn.Query(`EXPLAIN QUERY PLAN SELECT id FROM http_log`+
+		` WHERE (request_date, id) < (?, ?)`+
+		` ORDER BY request_date DESC, id DESC LIMIT 2;`, date, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+		var id, parent, notused int
+
+		err = rows.Scan(&id, &parent, &notused, &detail)
+		if err != nil {
+			t.Fatal(err)
+		}
+		plan += detail + "\n"
+	}
+	_ = rows.Close()
+	test.Assert(t, `query plan`, "SEARCH http_log USING COVERING INDEX http_log_date_id_idx (request_date<?)\n", plan)
+
+	var (
+		filter = httpLogFilter{Limit: 2}
+
+		page httpLogPage
+	)
+
+	page, err = searchHTTPLog(fw.conn, driverNameSqlite, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `page 1: len`, 2, len(page.List))
+	test.Assert(t, `page 1: backend`, `web`, page.List[0].BackendName)
+	test.Assert(t, `page 1: backend`, `api`, page.List[1].BackendName)
+	test.Assert(t, `page 1: request_date`, date, page.List[1].RequestDate)
+	test.Assert(t, `page 1: sample_weight`, 2.0, page.List[1].SampleWeight)
+
+	filter.cursor, err = parseHTTPLogCursor(page.Next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err = searchHTTPLog(fw.conn, driverNameSqlite, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `page 2: next`, ``, page.Next)
+	test.Assert(t, `page 2: len`, 1, len(page.List))
+
+	var got = page.List[0]
+	test.Assert(t, `page 2: request_date`, date.Add(-time.Minute),
+		got.RequestDate.UTC())
+	test.Assert(t, `page 2: header_request`,
+		map[string]string{`host`: `api.local`}, got.HeaderRequest)
+	test.Assert(t, `page 2: is_bot`, true, got.IsBot)
+
+	var q url.Values
+
+	q, err = url.ParseQuery(`backend=api&url_prefix=/v1/&from=2026-01-18T12:08:28%2B07:00`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter, err = parseHTTPLogFilter(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err = searchHTTPLog(fw.conn, driverNameSqlite, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `filter: len`, 1, len(page.List))
+	test.Assert(t, `filter: status_code`, int32(500), page.List[0].StatusCode)
+}
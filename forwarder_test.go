@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestCheckForwarders(t *testing.T) {
+	var gotPath []string
+
+	var srv = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		gotPath = append(gotPath, req.URL.Path)
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var (
+		dbFile = filepath.Join(t.TempDir(), `haminer.db`)
+		cfg    = NewConfig()
+	)
+
+	cfg.Forwarders = map[string]*ConfigForwarder{
+		forwarderKindQuestdb: {
+			URL: srv.URL,
+		},
+		forwarderKindSqlite: {
+			URL: dbFile,
+		},
+	}
+
+	var err error
+	for name, fwCfg := range cfg.Forwarders {
+		err = fwCfg.init(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_hostname = `localhost`
+
+	var out bytes.Buffer
+
+	// The SQLite database is not migrated, so the check failed.
+	err = CheckForwarders(cfg, &out)
+	test.Assert(t, `error`, true, strings.Contains(err.Error(),
+		`pending migration(s) from 0001_http_log.sql, run "haminer migrate" first`))
+
+	var fw *forwarderSqlite
+
+	fw, err = openForwarderSqlite(ConfigForwarder{URL: dbFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.close()
+
+	var ntable int
+
+	err = fw.conn.QueryRow(`SELECT count(*) FROM sqlite_master;`).Scan(&ntable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `sqlite tables`, 0, ntable)
+
+	err = Migrate(cfg, false, false, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out.Reset()
+	err = CheckForwarders(cfg, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `output`, true,
+		bytes.Contains(out.Bytes(), []byte("questdb: OK\n")))
+	test.Assert(t, `output`, true,
+		bytes.Contains(out.Bytes(), []byte("sqlite: OK\n")))
+
+	// The questdb "/exec" API is not called.
+	test.Assert(t, `questdb path`, []string{`/write`}, gotPath)
+}
@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// defGeoIPCheckInterval the minimum interval to check if the MaxMind DB
+// file has been changed on disk.
+const defGeoIPCheckInterval = time.Minute
+
+// geoIPCity the record in GeoIP2 or GeoLite2 City and Country database.
+type geoIPCity struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// geoIPASN the record in GeoIP2 or GeoLite2 ASN database.
+type geoIPASN struct {
+	Organization string `maxminddb:"autonomous_system_organization"`
+	Number       uint   `maxminddb:"autonomous_system_number"`
+}
+
+// geoIP enrich the HTTPLog with the location and autonomous system of
+// client IP.
+type geoIP struct {
+	city *mmdbFile
+	asn  *mmdbFile
+}
+
+// newGeoIP open the MaxMind DB files for city and ASN.
+// It will return nil if both paths are empty.
+func newGeoIP(cityPath, asnPath string) (geo *geoIP, err error) {
+	if len(cityPath) == 0 && len(asnPath) == 0 {
+		return nil, nil
+	}
+
+	geo = &geoIP{}
+
+	if len(cityPath) != 0 {
+		geo.city, err = openMMDBFile(cityPath)
+		if err != nil {
+			return nil, fmt.Errorf(`geoip_city: %w`, err)
+		}
+	}
+	if len(asnPath) != 0 {
+		geo.asn, err = openMMDBFile(asnPath)
+		if err != nil {
+			geo.close()
+			return nil, fmt.Errorf(`geoip_asn: %w`, err)
+		}
+	}
+	return geo, nil
+}
+
+// enrich set the GeoCountry, GeoCity, ASN, and ASOrg in halog based on
+// its ClientIP.
+// The client IP that is not found in the database is ignored.
+func (geo *geoIP) enrich(halog *HTTPLog) {
+	if geo == nil {
+		return
+	}
+
+	var ip = net.ParseIP(halog.ClientIP)
+	if ip == nil {
+		return
+	}
+
+	if geo.city != nil {
+		var rec geoIPCity
+
+		if geo.city.lookup(ip, &rec) {
+			halog.GeoCountry = rec.Country.ISOCode
+			halog.GeoCity = rec.City.Names[`en`]
+		}
+	}
+	if geo.asn != nil {
+		var rec geoIPASN
+
+		if geo.asn.lookup(ip, &rec) {
+			halog.ASN = int64(rec.Number)
+			halog.ASOrg = rec.Organization
+		}
+	}
+}
+
+// close all the MaxMind DB files.
+func (geo *geoIP) close() {
+	if geo == nil {
+		return
+	}
+	geo.city.close()
+	geo.asn.close()
+}
+
+// mmdbFile contains the reader of MaxMind DB file, that re-opened when the
+// file changes on disk.
+type mmdbFile struct {
+	reader *maxminddb.Reader
+
+	modTime   time.Time
+	checkedAt time.Time
+
+	path string
+	size int64
+
+	// checkInterval the minimum interval between checking the file
+	// changes.
+	checkInterval time.Duration
+
+	mtx sync.Mutex
+}
+
+func openMMDBFile(path string) (mmdb *mmdbFile, err error) {
+	mmdb = &mmdbFile{
+		path:          path,
+		checkInterval: defGeoIPCheckInterval,
+	}
+
+	err = mmdb.open()
+	if err != nil {
+		return nil, err
+	}
+	return mmdb, nil
+}
+
+// open the file and replace the current reader.
+func (mmdb *mmdbFile) open() (err error) {
+	var fi os.FileInfo
+
+	fi, err = os.Stat(mmdb.path)
+	if err != nil {
+		return err
+	}
+
+	var reader *maxminddb.Reader
+
+	reader, err = maxminddb.Open(mmdb.path)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, mmdb.path, err)
+	}
+
+	if mmdb.reader != nil {
+		_ = mmdb.reader.Close()
+	}
+
+	mmdb.reader = reader
+	mmdb.modTime = fi.ModTime()
+	mmdb.size = fi.Size()
+	mmdb.checkedAt = time.Now()
+
+	return nil
+}
+
+// reopen the file if its modification time or size has been changed
+// since the last time opened.
+// If the new file cannot be opened, the current reader is kept.
+func (mmdb *mmdbFile) reopen() {
+	var now = time.Now()
+
+	if now.Sub(mmdb.checkedAt) < mmdb.checkInterval {
+		return
+	}
+	mmdb.checkedAt = now
+
+	var fi, err = os.Stat(mmdb.path)
+	if err != nil {
+		return
+	}
+	if fi.ModTime().Equal(mmdb.modTime) && fi.Size() == mmdb.size {
+		return
+	}
+
+	err = mmdb.open()
+	if err != nil {
+		mlog.Errf(`mmdbFile: reopen: %s`, err)
+		return
+	}
+	mlog.Outf(`mmdbFile: %s reloaded`, mmdb.path)
+}
+
+// lookup the ip and decode its record into result.
+// It will return true if the ip is found.
+func (mmdb *mmdbFile) lookup(ip net.IP, result any) bool {
+	mmdb.mtx.Lock()
+	defer mmdb.mtx.Unlock()
+
+	if mmdb.reader == nil {
+		// The file has been closed.
+		return false
+	}
+
+	mmdb.reopen()
+
+	var (
+		offset uintptr
+		err    error
+	)
+
+	offset, err = mmdb.reader.LookupOffset(ip)
+	if err != nil || offset == maxminddb.NotFound {
+		return false
+	}
+
+	err = mmdb.reader.Decode(offset, result)
+	return err == nil
+}
+
+func (mmdb *mmdbFile) close() {
+	if mmdb == nil {
+		return
+	}
+
+	mmdb.mtx.Lock()
+	if mmdb.reader != nil {
+		_ = mmdb.reader.Close()
+		mmdb.reader = nil
+	}
+	mmdb.mtx.Unlock()
+}
@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestGeoIP_enrich(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		cityPath = filepath.Join(dir, `city.mmdb`)
+		asnPath  = filepath.Join(dir, `asn.mmdb`)
+		cfgPath  = filepath.Join(dir, `haminer.conf`)
+	)
+
+	writeTestMMDB(t, cityPath, `GeoLite2-City`, map[string]map[string]any{
+		`81.2.69.0/24`: {
+			`city`:    map[string]any{`names`: map[string]any{`en`: `London`}},
+			`country`: map[string]any{`iso_code`: `GB`},
+		},
+	})
+	writeTestMMDB(t, asnPath, `GeoLite2-ASN`, map[string]map[string]any{
+		`81.2.69.0/24`: {
+			`autonomous_system_number`:       uint32(20712),
+			`autonomous_system_organization`: `Andrews & Arnold Ltd, UK`,
+		},
+	})
+
+	var content = fmt.Sprintf("[haminer]\ngeoip_city = %s\ngeoip_asn = %s\n",
+		cityPath, asnPath)
+
+	var err = os.WriteFile(cfgPath, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg = NewConfig()
+
+	err = cfg.Load(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cfg.geoip.close)
+
+	var (
+		h      = &Haminer{cfg: cfg}
+		packet = `<134>Mar 17 05:08:28 haproxy[371]: %s:52722 [17/Mar/2024:05:08:28.886] fe-http be-http/be-http2 10/20/30/40/50 200 149 - - ---- 1/1/2/3/4 5/6 "GET / HTTP/1.1"`
+		halog  = ParseUDPPacket([]byte(fmt.Sprintf(packet, `81.2.69.160`)), nil)
+	)
+
+	h.enrich(cfg, halog)
+
+	test.Assert(t, `GeoCountry`, `GB`, halog.GeoCountry)
+	test.Assert(t, `GeoCity`, `London`, halog.GeoCity)
+	test.Assert(t, `ASN`, int64(20712), halog.ASN)
+	test.Assert(t, `ASOrg`, `Andrews & Arnold Ltd, UK`, halog.ASOrg)
+
+	var ilp bytes.Buffer
+
+	err = halog.writeIlp(&ilp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `writeIlp`, true, strings.Contains(ilp.String(),
+		`,geo_country=GB,geo_city=London,as_org=Andrews\ &\ Arnold\ Ltd\,\ UK,asn=20712 `))
+
+	// The IP that is not in the database is not enriched.
+	var private = ParseUDPPacket([]byte(fmt.Sprintf(packet, `10.0.0.1`)), nil)
+	h.enrich(cfg, private)
+	test.Assert(t, `private GeoCountry`, ``, private.GeoCountry)
+	test.Assert(t, `private ASN`, int64(0), private.ASN)
+
+	// The file is reloaded when it changes on disk.
+	var tmpPath = cityPath + `.tmp`
+	writeTestMMDB(t, tmpPath, `GeoLite2-City`, map[string]map[string]any{
+		`81.2.69.0/24`: {
+			`city`:    map[string]any{`names`: map[string]any{`en`: `Manchester`}},
+			`country`: map[string]any{`iso_code`: `GB`},
+		},
+	})
+	var modTime = time.Now().Add(time.Hour)
+	err = os.Chtimes(tmpPath, modTime, modTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Rename(tmpPath, cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.geoip.city.checkInterval = 0
+
+	halog = ParseUDPPacket([]byte(fmt.Sprintf(packet, `81.2.69.160`)), nil)
+	h.enrich(cfg, halog)
+	test.Assert(t, `GeoCity after reload`, `Manchester`, halog.GeoCity)
+}
+
+// writeTestMMDB write the IPv4 MaxMind DB file that contains the map of
+// network, in CIDR notation, and its record.
+// It only support the types used by the GeoIP databases: map, string,
+// uint16, uint32, uint64, and array of string.
+func writeTestMMDB(t *testing.T, path, dbType string, networks map[string]map[string]any) {
+	t.Helper()
+
+	const recordEmpty = 0
+
+	// Each record in the search tree is either empty, the index of
+	// next node plus one, or negative offset of data minus one.
+	var (
+		nodes = [][2]int{{recordEmpty, recordEmpty}}
+		data  bytes.Buffer
+	)
+
+	var cidrs = make([]string, 0, len(networks))
+	for cidr := range networks {
+		cidrs = append(cidrs, cidr)
+	}
+	slices.Sort(cidrs)
+
+	for _, cidr := range cidrs {
+		var prefix, err = netip.ParsePrefix(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var dataRecord = -(data.Len() + 1)
+		writeTestMMDBValue(&data, networks[cidr])
+
+		var (
+			ip   = prefix.Addr().As4()
+			bits = prefix.Bits()
+			node = 0
+		)
+		for x := range bits {
+			var bit = (ip[x/8] >> (7 - x%8)) & 1
+			if x == bits-1 {
+				nodes[node][bit] = dataRecord
+				break
+			}
+			if nodes[node][bit] <= 0 {
+				nodes = append(nodes, [2]int{recordEmpty, recordEmpty})
+				nodes[node][bit] = len(nodes)
+			}
+			node = nodes[node][bit] - 1
+		}
+	}
+
+	var (
+		nodeCount = len(nodes)
+		out       bytes.Buffer
+		rec       [4]byte
+	)
+	for _, node := range nodes {
+		for _, v := range node {
+			switch {
+			case v == recordEmpty:
+				v = nodeCount
+			case v > 0:
+				v--
+			default:
+				v = nodeCount + 16 + (-v - 1)
+			}
+			binary.BigEndian.PutUint32(rec[:], uint32(v))
+			out.Write(rec[1:])
+		}
+	}
+	out.Write(make([]byte, 16))
+	out.Write(data.Bytes())
+
+	out.WriteString("\xAB\xCD\xEFMaxMind.com")
+	writeTestMMDBValue(&out, map[string]any{
+		`binary_format_major_version`: uint16(2),
+		`binary_format_minor_version`: uint16(0),
+		`build_epoch`:                 uint64(1792281600),
+		`database_type`:               dbType,
+		`description`:                 map[string]any{`en`: `Test ` + dbType},
+		`ip_version`:                  uint16(4),
+		`languages`:                   []string{`en`},
+		`node_count`:                  uint32(nodeCount),
+		`record_size`:                 uint16(24),
+	})
+
+	var err = os.WriteFile(path, out.Bytes(), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTestMMDBValue write the value v using the MaxMind DB data section
+// format.
+func writeTestMMDBValue(out *bytes.Buffer, v any) {
+	// writeControl write the control byte of type typ with the size.
+	// The size must be less than 285.
+	var writeControl = func(typ, size int) {
+		var sizeBits = size
+		if size >= 29 {
+			sizeBits = 29
+		}
+		if typ <= 7 {
+			out.WriteByte(byte(typ<<5 | sizeBits))
+		} else {
+			out.WriteByte(byte(sizeBits))
+			out.WriteByte(byte(typ - 7))
+		}
+		if size >= 29 {
+			out.WriteByte(byte(size - 29))
+		}
+	}
+	var writeUint = func(typ int, v uint64) {
+		var raw [8]byte
+		binary.BigEndian.PutUint64(raw[:], v)
+		var b = bytes.TrimLeft(raw[:], "\x00")
+		writeControl(typ, len(b))
+		out.Write(b)
+	}
+
+	switch v := v.(type) {
+	case string:
+		writeControl(2, len(v))
+		out.WriteString(v)
+	case uint16:
+		writeUint(5, uint64(v))
+	case uint32:
+		writeUint(6, uint64(v))
+	case uint64:
+		writeUint(9, v)
+	case []string:
+		writeControl(11, len(v))
+		for _, s := range v {
+			writeTestMMDBValue(out, s)
+		}
+	case map[string]any:
+		var keys = make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		writeControl(7, len(keys))
+		for _, k := range keys {
+			writeTestMMDBValue(out, k)
+			writeTestMMDBValue(out, v[k])
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"log"
 	"net"
 	"os"
+	"slices"
+	"sync"
 	"time"
 
 	"git.sr.ht/~shulhan/pakakeh.go/lib/memfs"
@@ -39,9 +41,29 @@ type Haminer struct {
 
 	httpd *httpServer
 
-	httpLogq  chan *HTTPLog
-	ff        []Forwarder
+	httpLogq chan *HTTPLog
+
+	// reloadq receive the new configuration and forwarders to be
+	// swapped by produce.
+	reloadq chan *reload
+
+	// stopq closed by Stop to stop the produce and the pending
+	// Reload.
+	stopq    chan struct{}
+	stopOnce sync.Once
+
+	ff []Forwarder
+
+	// cfgMtx protect the cfg and ff when its swapped by produce, while
+	// being read by consume.
+	cfgMtx sync.RWMutex
+
 	isRunning bool
+
+	// checkOnly if true, the forwarders are created without creating or
+	// migrating the database schema, and without starting the
+	// partition maintainer.
+	checkOnly bool
 }
 
 func initHostname() {
@@ -68,6 +90,8 @@ func NewHaminer(cfg *Config) (h *Haminer, err error) {
 	h = &Haminer{
 		cfg:      cfg,
 		httpLogq: make(chan *HTTPLog, 30),
+		reloadq:  make(chan *reload),
+		stopq:    make(chan struct{}),
 		ff:       make([]Forwarder, 0),
 	}
 
@@ -85,6 +109,8 @@ func NewHaminer(cfg *Config) (h *Haminer, err error) {
 		return nil, fmt.Errorf(`%s: %w`, logp, err)
 	}
 
+	h.storeDatabase()
+
 	return h, nil
 }
 
@@ -93,54 +119,124 @@ func (h *Haminer) createForwarder() (err error) {
 		logp = `createForwarder`
 
 		fwCfg  *ConfigForwarder
+		fw     Forwarder
 		fwName string
 	)
 
 	for fwName, fwCfg = range h.cfg.Forwarders {
-		var influxc *forwarderInfluxd
+		fw, err = h.newForwarder(h.cfg, fwName, fwCfg)
+		if err != nil {
+			h.closeForwarders()
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+		if fw != nil {
+			h.ff = append(h.ff, fw)
+		}
+	}
+	return nil
+}
 
-		switch fwName {
-		case forwarderKindInfluxd:
-			influxc = newForwarderInfluxd(fwCfg)
-			if influxc != nil {
-				h.ff = append(h.ff, influxc)
-			}
+// closeForwarders release the resources of all forwarders.
+func (h *Haminer) closeForwarders() {
+	for _, fw := range h.ff {
+		closeForwarder(fw)
+	}
+	h.ff = nil
+}
+
+// newForwarder create new Forwarder based on its name.
+// It will return nil Forwarder without an error if the forwarder is not
+// configured or cannot be connected.
+func (h *Haminer) newForwarder(cfg *Config, fwName string, fwCfg *ConfigForwarder) (fw Forwarder, err error) {
+	var logp = `newForwarder`
+
+	switch fwName {
+	case forwarderKindInfluxd:
+		var influxc = newForwarderInfluxd(fwCfg)
+		if influxc != nil {
+			return influxc, nil
+		}
 
-		case forwarderKindQuestdb:
-			var questc *forwarderQuestdb
+	case forwarderKindQuestdb:
+		var questc *forwarderQuestdb
 
+		if h.checkOnly {
+			questc, err = openForwarderQuestdb(fwCfg)
+		} else {
 			questc, err = newForwarderQuestdb(fwCfg)
-			if err != nil {
-				log.Printf(`%s: %s: %s`, logp, fwName, err)
-				continue
-			}
-			if questc == nil {
-				continue
-			}
-			h.ff = append(h.ff, questc)
+		}
+		if err != nil {
+			log.Printf(`%s: %s: %s`, logp, fwName, err)
+			return nil, nil
+		}
+		if questc != nil {
+			return questc, nil
+		}
 
-		case forwarderKindPostgresql:
-			if fwCfg.URL == `` {
-				continue
-			}
+	case forwarderKindPostgresql:
+		if fwCfg.URL == `` {
+			return nil, nil
+		}
 
-			var pgc *forwarderPostgresql
+		var pgc *forwarderPostgresql
 
-			pgc, err = newForwarderPostgresql(*fwCfg)
+		pgc, err = newForwarderPostgresql(*fwCfg)
+		if err != nil {
+			log.Printf(`%s: %s: %s`, logp, fwName, err)
+			return nil, nil
+		}
+
+		pgc.reqHeaders = cfg.RequestHeaders
+
+		if h.checkOnly {
+			pgc.noDDL = true
+			err = pgc.checkSchema()
 			if err != nil {
-				log.Printf(`%s: %s: %s`, logp, fwName, err)
-				continue
+				_ = pgc.close()
+				return nil, fmt.Errorf(`%s: %w`, logp, err)
 			}
+			return pgc, nil
+		}
 
-			err = pgc.conn.Migrate(``, memfsDatabase)
+		err = pgc.migrate()
+		if err != nil {
+			_ = pgc.close()
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
+		}
+
+		if len(pgc.cfg.PartitionBy) != 0 {
+			go pgc.partitionMaintainer()
+		}
+
+		return pgc, nil
+
+	case forwarderKindSqlite:
+		if fwCfg.URL == `` {
+			return nil, nil
+		}
+
+		var sqlitec *forwarderSqlite
+
+		if !h.checkOnly {
+			sqlitec, err = newForwarderSqlite(*fwCfg)
 			if err != nil {
-				return fmt.Errorf(`%s: %w`, logp, err)
+				return nil, fmt.Errorf(`%s: %w`, logp, err)
 			}
+			return sqlitec, nil
+		}
 
-			h.ff = append(h.ff, pgc)
+		sqlitec, err = openForwarderSqlite(*fwCfg)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
+		}
+		err = sqlitec.checkSchema()
+		if err != nil {
+			_ = sqlitec.close()
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
 		}
+		return sqlitec, nil
 	}
-	return nil
+	return nil, nil
 }
 
 // Start will listen for UDP packet and start consuming log, parse, and
@@ -171,19 +267,30 @@ func (h *Haminer) Start() (err error) {
 
 // filter will return true if log is accepted; otherwise it will return false.
 func (h *Haminer) filter(halog *HTTPLog) bool {
-	if halog.BackendName == `-` {
+	var cfg = h.config()
+
+	if halog.IsError && !cfg.IncludeError {
 		return false
 	}
-	if len(h.cfg.AcceptBackend) == 0 {
-		return true
+
+	if len(cfg.AcceptBackend) != 0 &&
+		!slices.Contains(cfg.AcceptBackend, halog.BackendName) {
+		return false
 	}
 
-	for _, be := range h.cfg.AcceptBackend {
-		if halog.BackendName == be {
+	for _, expr := range cfg.drops {
+		if expr.eval(halog) {
+			return false
+		}
+	}
+	if len(cfg.filters) == 0 {
+		return true
+	}
+	for _, expr := range cfg.filters {
+		if expr.eval(halog) {
 			return true
 		}
 	}
-
 	return false
 }
 
@@ -191,7 +298,9 @@ func (h *Haminer) consume() {
 	var (
 		packet = make([]byte, 4096)
 
+		cfg   *Config
 		halog *HTTPLog
+		tlog  *tailLog
 		err   error
 		n     int
 		ok    bool
@@ -203,15 +312,24 @@ func (h *Haminer) consume() {
 			continue
 		}
 
-		if h.httpd != nil {
-			select {
-			case h.httpd.rawlogq <- string(packet[:n]):
-			default:
-				// Log queue is full.
+		cfg = h.config()
+
+		halog, tlog = h.parsePacket(cfg, packet[:n])
+
+		if tlog != nil {
+			ok = cfg.piiPolicy(``).applyTail(tlog)
+			if ok && !h.httpd.showCookie {
+				ok = tlog.omitCookie()
+			}
+			if ok {
+				select {
+				case h.httpd.rawlogq <- tlog:
+				default:
+					// Log queue is full.
+				}
 			}
 		}
 
-		halog = ParseUDPPacket(packet[:n], h.cfg.RequestHeaders)
 		if halog == nil {
 			continue
 		}
@@ -225,10 +343,64 @@ func (h *Haminer) consume() {
 	}
 }
 
+// parsePacket parse the packet into HTTPLog and enrich it.
+// If the HTTP server is running, it also return the tailLog to be
+// published, with the HTTP query normalized.
+// The raw log is copied before parsing, because the parser modify the
+// packet in place.
+func (h *Haminer) parsePacket(cfg *Config, packet []byte) (halog *HTTPLog, tlog *tailLog) {
+	var raw string
+	if h.httpd != nil {
+		raw = string(packet)
+	}
+
+	halog = ParseUDPPacket(packet, cfg.RequestHeaders)
+	if halog != nil {
+		h.enrich(cfg, halog)
+	}
+
+	if h.httpd != nil {
+		tlog = newTailLog(raw, halog)
+		tlog.processQuery(cfg.queryp)
+	}
+	return halog, tlog
+}
+
+// enrich set the fields in halog that derived from its original values,
+// before the PII policy applied.
+func (h *Haminer) enrich(cfg *Config, halog *HTTPLog) {
+	cfg.geoip.enrich(halog)
+	enrichUserAgent(halog)
+	halog.ClientLabel = cfg.clientLabeler.label(halog.ClientIP)
+	halog.ClientHost = cfg.rdns.lookup(halog.ClientIP)
+}
+
+// preprocess normalize the HTTP query and set the tag values in halog by
+// applying the pre-processing rules to the original values.
 func (h *Haminer) preprocess(halog *HTTPLog) {
-	halog.tagHTTPURL = halog.HTTPURL
-	for _, retag := range h.cfg.retags {
-		halog.tagHTTPURL = retag.preprocess("http_url", halog.tagHTTPURL)
+	var cfg = h.cfg
+
+	if cfg.queryp != nil {
+		halog.HTTPQuery = cfg.queryp.process(halog.HTTPQuery)
+	}
+
+	halog.tagHTTPURL = cfg.preprocessTag(tagNameHTTPURL, halog.HTTPURL)
+	if cfg.urlt != nil {
+		halog.tagHTTPURL = cfg.urlt.template(halog.BackendName, halog.tagHTTPURL)
+	}
+	halog.tagHTTPQuery = cfg.preprocessTag(tagNameHTTPQuery, halog.HTTPQuery)
+	halog.tagClientIP = cfg.preprocessTag(tagNameClientIP, halog.ClientIP)
+	halog.tagServerName = cfg.preprocessTag(tagNameServer, halog.ServerName)
+	halog.tagBackendName = cfg.preprocessTag(tagNameBackend, halog.BackendName)
+
+	halog.tagHeaderRequest = halog.HeaderRequest
+	if !cfg.hasHeaderRetag || len(halog.HeaderRequest) == 0 {
+		return
+	}
+
+	halog.tagHeaderRequest = make(map[string]string, len(halog.HeaderRequest))
+	for name, value := range halog.HeaderRequest {
+		halog.tagHeaderRequest[name] = cfg.preprocessTag(tagNameHeaderPrefix+name, value)
 	}
 }
 
@@ -240,6 +412,14 @@ func (h *Haminer) produce() {
 		select {
 		case halog := <-h.httpLogq:
 			h.preprocess(halog)
+			if h.httpd != nil {
+				h.httpd.dash.add(time.Now(), h.cfg.piiPolicy(``).applyOne(halog))
+			}
+			// The dashboard receive all logs, while the
+			// forwarders receive only the sample.
+			if !h.cfg.sampler.sample(halog) {
+				continue
+			}
 			halogs = append(halogs, halog)
 
 		case <-ticker.C:
@@ -247,15 +427,36 @@ func (h *Haminer) produce() {
 				continue
 			}
 
-			for _, fwder := range h.ff {
-				fwder.Forwards(halogs)
-			}
+			h.forwards(halogs)
 
 			halogs = halogs[:0]
+
+		case rl := <-h.reloadq:
+			// Forward the in-flight logs using the old
+			// forwarders before swapping them.
+			if len(halogs) != 0 {
+				h.forwards(halogs)
+				halogs = halogs[:0]
+			}
+
+			h.swap(rl)
+			ticker.Reset(h.cfg.ForwardInterval)
+			close(rl.done)
+
+		case <-h.stopq:
+			return
 		}
 	}
 }
 
+// forwards the halogs to all forwarders, after applying the PII policy of
+// each forwarder.
+func (h *Haminer) forwards(halogs []*HTTPLog) {
+	for _, fwder := range h.ff {
+		fwder.Forwards(h.cfg.piiPolicy(forwarderName(fwder)).apply(halogs))
+	}
+}
+
 // Stop will close UDP server and clear all resources.
 func (h *Haminer) Stop() {
 	var (
@@ -272,6 +473,11 @@ func (h *Haminer) Stop() {
 	}
 
 	h.isRunning = false
+	if h.stopq != nil {
+		h.stopOnce.Do(func() {
+			close(h.stopq)
+		})
+	}
 
 	if h.udpConn != nil {
 		err = h.udpConn.Close()
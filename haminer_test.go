@@ -8,6 +8,8 @@ import (
 	"flag"
 	"os"
 	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
 )
 
 var testIntegration bool
@@ -19,3 +21,163 @@ func TestMain(m *testing.M) {
 	var status = m.Run()
 	os.Exit(status)
 }
+
+func TestHaminer_preprocess(t *testing.T) {
+	var cfg = &Config{
+		HTTPURL:             []string{`/[0-9]+ => /-`},
+		PreprocessHTTPQuery: []string{`token=[^&]+ => token=-`},
+		PreprocessClientIP:  []string{`^(\d+\.\d+\.\d+)\.\d+$ => $1.0`},
+		PreprocessServer:    []string{`-[0-9]+$ => `},
+		PreprocessBackend:   []string{`^be- => `},
+		PreprocessHeader: []string{
+			`host: ^www\. => `,
+			`invalid`,
+		},
+	}
+
+	var err = cfg.parsePreprocessTag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `hasHeaderRetag`, true, cfg.hasHeaderRetag)
+
+	var (
+		h     = &Haminer{cfg: cfg}
+		halog = &HTTPLog{
+			ClientIP:    `10.1.2.3`,
+			ServerName:  `api-01`,
+			BackendName: `be-api`,
+			HTTPURL:     `/users/1000`,
+			HTTPQuery:   `a=1&token=secret`,
+			HeaderRequest: map[string]string{
+				`host`:    `www.example.com`,
+				`referer`: `www.example.com`,
+			},
+		}
+	)
+
+	h.preprocess(halog)
+
+	test.Assert(t, `tagHTTPURL`, `/users/-`, halog.tagHTTPURL)
+	test.Assert(t, `tagHTTPQuery`, `a=1&token=-`, halog.tagHTTPQuery)
+	test.Assert(t, `tagClientIP`, `10.1.2.0`, halog.tagClientIP)
+	test.Assert(t, `tagServerName`, `api`, halog.tagServerName)
+	test.Assert(t, `tagBackendName`, `api`, halog.tagBackendName)
+	test.Assert(t, `tagHeaderRequest`, map[string]string{
+		`host`:    `example.com`,
+		`referer`: `www.example.com`,
+	}, halog.tagHeaderRequest)
+
+	// The original values are kept.
+	test.Assert(t, `HTTPURL`, `/users/1000`, halog.HTTPURL)
+	test.Assert(t, `ClientIP`, `10.1.2.3`, halog.ClientIP)
+	test.Assert(t, `HeaderRequest host`, `www.example.com`,
+		halog.HeaderRequest[`host`])
+}
+
+func TestHaminer_parsePacket(t *testing.T) {
+	var (
+		cfg = &Config{
+			RequestHeaders: []string{`host`},
+			queryp:         newQueryProcessor(nil, nil, nil),
+		}
+		h   = &Haminer{httpd: &httpServer{}}
+		raw = `<134>Mar 17 05:08:28 localhost haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 sid=abc - ---- 1/1/2/3/4 5/6 {example.com} "GET /users/1?token=abc HTTP/1.1"`
+
+		halog, tlog = h.parsePacket(cfg, []byte(raw))
+	)
+	if halog == nil {
+		t.Fatal(`cannot parse packet`)
+	}
+
+	var exp = `<134>Mar 17 05:08:28 localhost haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 sid=abc - ---- 1/1/2/3/4 5/6 {example.com} "GET /users/1?token=xxxxx HTTP/1.1"`
+	test.Assert(t, `raw`, exp, tlog.raw)
+	test.Assert(t, `HTTPQuery`, `token=xxxxx`, tlog.halog.HTTPQuery)
+	test.Assert(t, `BackendName`, `be-a`, tlog.halog.BackendName)
+
+	// Without HTTP server, no tailLog is created.
+	h.httpd = nil
+	_, tlog = h.parsePacket(cfg, []byte(raw))
+	test.Assert(t, `tailLog`, true, tlog == nil)
+}
+
+func TestHaminer_filter(t *testing.T) {
+	var (
+		cfg = &Config{
+			AcceptBackend: []string{`be-api`, `be-static`},
+		}
+		err error
+	)
+
+	cfg.filters, err = compileFilterExprs([]string{
+		`status_code >= 400`,
+		`http_method == "POST"`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.drops, err = compileFilterExprs([]string{
+		`http_url =~ "^/health"`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		h     = &Haminer{cfg: cfg}
+		cases = []struct {
+			halog *HTTPLog
+			desc  string
+			exp   bool
+		}{{
+			desc: `Match one of filter`,
+			halog: &HTTPLog{
+				BackendName: `be-api`,
+				HTTPMethod:  `GET`,
+				HTTPURL:     `/users`,
+				StatusCode:  404,
+			},
+			exp: true,
+		}, {
+			desc: `Not match any filter`,
+			halog: &HTTPLog{
+				BackendName: `be-api`,
+				HTTPMethod:  `GET`,
+				HTTPURL:     `/users`,
+				StatusCode:  200,
+			},
+		}, {
+			desc: `Match drop`,
+			halog: &HTTPLog{
+				BackendName: `be-api`,
+				HTTPMethod:  `POST`,
+				HTTPURL:     `/health`,
+				StatusCode:  500,
+			},
+		}, {
+			desc: `Not in accept_backend`,
+			halog: &HTTPLog{
+				BackendName: `be-admin`,
+				HTTPMethod:  `POST`,
+				HTTPURL:     `/users`,
+			},
+		}}
+	)
+
+	for _, c := range cases {
+		test.Assert(t, c.desc, c.exp, h.filter(c.halog))
+	}
+
+	var errLog = &HTTPLog{
+		BackendName: `be-api`,
+		ServerName:  serverNameNoServer,
+		HTTPMethod:  `GET`,
+		HTTPURL:     `/users`,
+		StatusCode:  503,
+		IsError:     true,
+	}
+	test.Assert(t, `Error log`, false, h.filter(errLog))
+
+	cfg.IncludeError = true
+	test.Assert(t, `Error log with include_error`, true, h.filter(errLog))
+}
@@ -7,6 +7,8 @@ package haminer
 import (
 	"bytes"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
@@ -26,7 +28,6 @@ const (
 		`,frontend=%s` +
 		`,http_method=%s` +
 		`,http_url=%s` +
-		`,http_query=%q` +
 		`,http_proto=%s` +
 		`,http_status=%d` +
 		`,term_state=%s` +
@@ -45,58 +46,185 @@ const (
 		`conn_retries=%d,` +
 		`queue_server=%d,` +
 		`queue_backend=%d,` +
-		`bytes_read=%d`
+		`bytes_read=%d,` +
+		`sample_weight=%g`
 )
 
 const tableNameHTTPLog = `http_log`
 
+// List of values logged by HAProxy when the request is not forwarded to
+// any server.
+const (
+	// backendNameNone the backend name when no backend is selected.
+	backendNameNone = `-`
+
+	// serverNameNoServer the server name when no server is selected,
+	// for example the request is denied, invalid, or no server is
+	// available.
+	serverNameNoServer = `<NOSRV>`
+
+	// httpRequestBad the HTTP request line when the request cannot be
+	// parsed by HAProxy.
+	httpRequestBad = `<BADREQ>`
+)
+
+// ilpTagEscaper escape the comma, equal sign, and space in the ILP tag
+// value.
+var ilpTagEscaper = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+
+// ilpFieldEscaper escape the backslash and double quote in the ILP string
+// field value.
+var ilpFieldEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
 // HTTPLog contains the mapping of haproxy HTTP log format to Go struct.
 //
 // Reference: https://cbonte.github.io/haproxy-dconv/1.7/configuration.html#8.2.3
 type HTTPLog struct {
-	RequestDate time.Time
+	RequestDate time.Time `json:"request_date"`
+
+	HeaderRequest  map[string]string `json:"header_request,omitempty"`
+	HeaderResponse map[string]string `json:"header_response,omitempty"`
+
+	rawHeaderRequest string
+
+	ClientIP string `json:"client_ip"`
+
+	// ClientLabel the name of network that contains the ClientIP, from
+	// the option "label" in section "enrich" "client_ip".
+	ClientLabel string `json:"client_label"`
+
+	// ClientHost the host name of ClientIP from reverse DNS.
+	ClientHost string `json:"client_host"`
+
+	// The location and autonomous system of ClientIP, from the GeoIP
+	// database.
+	// They are empty if the GeoIP is not configured or the ClientIP is
+	// not found.
+
+	GeoCountry string `json:"geo_country"`
+	GeoCity    string `json:"geo_city"`
+	ASOrg      string `json:"as_org"`
+	ASN        int64  `json:"asn"`
+
+	// The browser and OS name, and the device type parsed from the
+	// captured request header "user_agent".
+
+	UABrowser string `json:"ua_browser"`
+	UAOS      string `json:"ua_os"`
+	UADevice  string `json:"ua_device"`
+
+	FrontendName string `json:"frontend_name"`
+	BackendName  string `json:"backend_name"`
+	ServerName   string `json:"server_name"`
+
+	HTTPProto  string `json:"http_proto"`
+	HTTPMethod string `json:"http_method"`
+	HTTPURL    string `json:"http_url"`
+	HTTPQuery  string `json:"http_query"`
+
+	// The tag values, after pre-processed, that are forwarded to the
+	// tag based forwarders, Influxd and Questdb.
+	// The SQL based forwarder store the original values.
+
+	tagHTTPURL       string
+	tagHTTPQuery     string
+	tagClientIP      string
+	tagServerName    string
+	tagBackendName   string
+	tagHeaderRequest map[string]string
+
+	CookieRequest    string `json:"cookie_request,omitempty"`
+	CookieResponse   string `json:"cookie_response,omitempty"`
+	TerminationState string `json:"termination_state"`
+
+	BytesRead int64 `json:"bytes_read"`
+
+	// SampleWeight the number of logs represented by this log, set by
+	// the sample rules, default to 1.
+	SampleWeight float64 `json:"sample_weight"`
 
-	HeaderRequest  map[string]string
-	HeaderResponse map[string]string
+	StatusCode int32 `json:"status_code"`
+	ClientPort int32 `json:"client_port"`
 
-	rawHeaderRequest  string
-	rawHeaderResponse string
+	TimeRequest  int32 `json:"time_request"`
+	TimeWait     int32 `json:"time_wait"`
+	TimeConnect  int32 `json:"time_connect"`
+	TimeResponse int32 `json:"time_response"`
+	TimeAll      int32 `json:"time_all"`
 
-	ClientIP string
+	ConnActive   int32 `json:"conn_active"`
+	ConnFrontend int32 `json:"conn_frontend"`
+	ConnBackend  int32 `json:"conn_backend"`
+	ConnServer   int32 `json:"conn_server"`
+	Retries      int32 `json:"retries"`
 
-	FrontendName string
-	BackendName  string
-	ServerName   string
+	ServerQueue  int32 `json:"server_queue"`
+	BackendQueue int32 `json:"backend_queue"`
 
-	HTTPProto  string
-	HTTPMethod string
-	HTTPURL    string
-	HTTPQuery  string
-	tagHTTPURL string
+	// IsBot true if the "user_agent" is bot, crawler, or HTTP client
+	// library.
+	IsBot bool `json:"is_bot"`
 
-	CookieRequest    string
-	CookieResponse   string
-	TerminationState string
+	// IsError true if the request is not forwarded to any server, where
+	// the server name is "<NOSRV>" or the backend name is "-".
+	IsError bool `json:"is_error"`
+}
+
+// omitCookie remove the request and response cookies that are not masked
+// by the PII policy, so they are not exposed by the HTTP API.
+func (halog *HTTPLog) omitCookie() {
+	if halog.CookieRequest != redactedValue {
+		halog.CookieRequest = ``
+	}
+	if halog.CookieResponse != redactedValue {
+		halog.CookieResponse = ``
+	}
+}
 
-	BytesRead int64
+// sqlJSONMap map the HTTP headers into JSONB column.
+type sqlJSONMap map[string]string
 
-	StatusCode int32
-	ClientPort int32
+// Scan implement the [sql.Scanner] interface.
+func (jmap *sqlJSONMap) Scan(src any) (err error) {
+	var raw []byte
 
-	TimeRequest  int32
-	TimeWait     int32
-	TimeConnect  int32
-	TimeResponse int32
-	TimeAll      int32
+	switch v := src.(type) {
+	case nil:
+		*jmap = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf(`sqlJSONMap: unsupported type %T`, src)
+	}
 
-	ConnActive   int32
-	ConnFrontend int32
-	ConnBackend  int32
-	ConnServer   int32
-	Retries      int32
+	var m map[string]string
 
-	ServerQueue  int32
-	BackendQueue int32
+	err = json.Unmarshal(raw, &m)
+	if err != nil {
+		return fmt.Errorf(`sqlJSONMap: %w`, err)
+	}
+	*jmap = m
+	return nil
+}
+
+// Value implement the [driver.Valuer] interface.
+// The JSON is returned as string, so it will not escaped as bytea when
+// using COPY.
+func (jmap sqlJSONMap) Value() (v driver.Value, err error) {
+	if jmap == nil {
+		return nil, nil
+	}
+
+	var raw []byte
+
+	raw, err = json.Marshal(map[string]string(jmap))
+	if err != nil {
+		return nil, fmt.Errorf(`sqlJSONMap: %w`, err)
+	}
+	return string(raw), nil
 }
 
 // listHTTPLog fetch all HTTPLog record from database.
@@ -166,7 +294,9 @@ func Parse(in []byte, reqHeaders []string) (httpLog *HTTPLog) {
 
 	var ok bool
 
-	httpLog = &HTTPLog{}
+	httpLog = &HTTPLog{
+		SampleWeight: 1,
+	}
 
 	httpLog.ClientIP, ok = parseToString(in, ':')
 	if !ok {
@@ -208,9 +338,8 @@ func Parse(in []byte, reqHeaders []string) (httpLog *HTTPLog) {
 		return nil
 	}
 
-	if httpLog.ServerName == `<NOSRV>` {
-		return nil
-	}
+	httpLog.IsError = httpLog.ServerName == serverNameNoServer ||
+		httpLog.BackendName == backendNameNone
 
 	ok = httpLog.parseConnectionTimes(in)
 	if !ok {
@@ -403,6 +532,19 @@ func (httpLog *HTTPLog) generateSQLMeta(driver string, kind libsql.DMLKind) (met
 
 	meta.Bind(`request_date`, &httpLog.RequestDate)
 	meta.Bind(`client_ip`, &httpLog.ClientIP)
+	meta.Bind(`client_label`, &httpLog.ClientLabel)
+	meta.Bind(`client_host`, &httpLog.ClientHost)
+
+	meta.Bind(`geo_country`, &httpLog.GeoCountry)
+	meta.Bind(`geo_city`, &httpLog.GeoCity)
+	meta.Bind(`asn`, &httpLog.ASN)
+	meta.Bind(`as_org`, &httpLog.ASOrg)
+
+	meta.Bind(`ua_browser`, &httpLog.UABrowser)
+	meta.Bind(`ua_os`, &httpLog.UAOS)
+	meta.Bind(`ua_device`, &httpLog.UADevice)
+	meta.Bind(`is_bot`, &httpLog.IsBot)
+	meta.Bind(`is_error`, &httpLog.IsError)
 
 	meta.Bind(`frontend_name`, &httpLog.FrontendName)
 	meta.Bind(`backend_name`, &httpLog.BackendName)
@@ -413,14 +555,15 @@ func (httpLog *HTTPLog) generateSQLMeta(driver string, kind libsql.DMLKind) (met
 	meta.Bind(`http_url`, &httpLog.HTTPURL)
 	meta.Bind(`http_query`, &httpLog.HTTPQuery)
 
-	meta.Bind(`header_request`, &httpLog.rawHeaderRequest)
-	meta.Bind(`header_response`, &httpLog.rawHeaderResponse)
+	meta.Bind(`header_request`, (*sqlJSONMap)(&httpLog.HeaderRequest))
+	meta.Bind(`header_response`, (*sqlJSONMap)(&httpLog.HeaderResponse))
 
 	meta.Bind(`cookie_request`, &httpLog.CookieRequest)
 	meta.Bind(`cookie_response`, &httpLog.CookieResponse)
 	meta.Bind(`termination_state`, &httpLog.TerminationState)
 
 	meta.Bind(`bytes_read`, &httpLog.BytesRead)
+	meta.Bind(`sample_weight`, &httpLog.SampleWeight)
 	meta.Bind(`status_code`, &httpLog.StatusCode)
 	meta.Bind(`client_port`, &httpLog.ClientPort)
 
@@ -463,8 +606,7 @@ func (httpLog *HTTPLog) parseHeaderRequest(in []byte, reqHeaders []string) (ok b
 	}
 
 	end := bytes.IndexByte(in, '}')
-	// Either '}' not found or its empty as in '{}'.
-	if end <= 1 {
+	if end < 0 {
 		return
 	}
 
@@ -487,6 +629,16 @@ func (httpLog *HTTPLog) parseHeaderRequest(in []byte, reqHeaders []string) (ok b
 }
 
 func (httpLog *HTTPLog) parseHTTP(in []byte) (ok bool) {
+	if bytes.HasPrefix(in, []byte(httpRequestBad+`"`)) {
+		// The request line is logged as "<BADREQ>", we set the
+		// method with it and the URL and protocol with "-" since
+		// the tags cannot be empty.
+		httpLog.HTTPMethod = httpRequestBad
+		httpLog.HTTPURL = `-`
+		httpLog.HTTPProto = `-`
+		return true
+	}
+
 	httpLog.HTTPMethod, ok = parseToString(in, ' ')
 	if !ok {
 		return
@@ -522,29 +674,38 @@ func (httpLog *HTTPLog) writeIlp(out io.Writer) (err error) {
 	_, err = fmt.Fprintf(out, influxdTags,
 		// tags
 		_hostname,
-		httpLog.ServerName,
-		httpLog.BackendName,
+		httpLog.tagServerName,
+		httpLog.tagBackendName,
 		httpLog.FrontendName,
 		httpLog.HTTPMethod,
-		httpLog.HTTPURL,
-		httpLog.HTTPQuery,
+		httpLog.tagHTTPURL,
 		httpLog.HTTPProto,
 		httpLog.StatusCode,
 		httpLog.TerminationState,
-		httpLog.ClientIP,
+		httpLog.tagClientIP,
 		httpLog.ClientPort,
 	)
 	if err != nil {
 		return err
 	}
 
-	for k, v = range httpLog.HeaderRequest {
+	err = httpLog.writeIlpTagHTTPQuery(out)
+	if err != nil {
+		return err
+	}
+
+	for k, v = range httpLog.tagHeaderRequest {
 		_, err = fmt.Fprintf(out, `,%s=%s`, k, v)
 		if err != nil {
 			return err
 		}
 	}
 
+	err = httpLog.writeIlpEnrichTags(out)
+	if err != nil {
+		return err
+	}
+
 	_, err = out.Write([]byte(` `))
 	if err != nil {
 		return err
@@ -557,11 +718,17 @@ func (httpLog *HTTPLog) writeIlp(out io.Writer) (err error) {
 		httpLog.ConnServer, httpLog.Retries,
 		httpLog.ServerQueue, httpLog.BackendQueue,
 		httpLog.BytesRead,
+		httpLog.SampleWeight,
 	)
 	if err != nil {
 		return err
 	}
 
+	err = httpLog.writeIlpEnrichFields(out)
+	if err != nil {
+		return err
+	}
+
 	_, err = fmt.Fprintf(out, " %d\n", httpLog.RequestDate.UnixNano())
 	if err != nil {
 		return err
@@ -569,3 +736,73 @@ func (httpLog *HTTPLog) writeIlp(out io.Writer) (err error) {
 
 	return nil
 }
+
+// writeIlpTagHTTPQuery write the tag http_query, only if its value is not
+// empty, since the ILP does not allow empty tag value.
+func (httpLog *HTTPLog) writeIlpTagHTTPQuery(out io.Writer) (err error) {
+	if len(httpLog.tagHTTPQuery) == 0 {
+		return nil
+	}
+	_, err = fmt.Fprintf(out, `,http_query=%s`, ilpTagEscaper.Replace(httpLog.tagHTTPQuery))
+	return err
+}
+
+// writeIlpEnrichTags write the tags from the enrichment, only if its value
+// is not empty, and the tag "is_error" only if the log is error.
+func (httpLog *HTTPLog) writeIlpEnrichTags(out io.Writer) (err error) {
+	var tags = []struct {
+		name  string
+		value string
+	}{
+		{`client_label`, httpLog.ClientLabel},
+		{`geo_country`, httpLog.GeoCountry},
+		{`geo_city`, httpLog.GeoCity},
+		{`as_org`, httpLog.ASOrg},
+		{`ua_browser`, httpLog.UABrowser},
+		{`ua_os`, httpLog.UAOS},
+		{`ua_device`, httpLog.UADevice},
+	}
+	for _, tag := range tags {
+		if len(tag.value) == 0 {
+			continue
+		}
+		_, err = fmt.Fprintf(out, `,%s=%s`, tag.name, ilpTagEscaper.Replace(tag.value))
+		if err != nil {
+			return err
+		}
+	}
+	if httpLog.ASN != 0 {
+		_, err = fmt.Fprintf(out, `,asn=%d`, httpLog.ASN)
+		if err != nil {
+			return err
+		}
+	}
+	if len(httpLog.UADevice) != 0 {
+		_, err = fmt.Fprintf(out, `,is_bot=%t`, httpLog.IsBot)
+		if err != nil {
+			return err
+		}
+	}
+	if httpLog.IsError {
+		_, err = out.Write([]byte(`,is_error=true`))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeIlpEnrichFields write the fields from the enrichment, only if its
+// value is not empty.
+// The client host is written as string field, not as tag, because its
+// values is unbounded and would explode the series cardinality.
+func (httpLog *HTTPLog) writeIlpEnrichFields(out io.Writer) (err error) {
+	if len(httpLog.ClientHost) != 0 {
+		_, err = fmt.Fprintf(out, `,client_host="%s"`,
+			ilpFieldEscaper.Replace(httpLog.ClientHost))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
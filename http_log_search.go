@@ -0,0 +1,388 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	liberrors "git.sr.ht/~shulhan/pakakeh.go/lib/errors"
+	libsql "git.sr.ht/~shulhan/pakakeh.go/lib/sql"
+)
+
+const (
+	defSearchLimit = 100
+	maxSearchLimit = 1000
+)
+
+// httpLogFilter contains the parameters to search the HTTP logs in
+// database.
+// Each non-zero field is joined with AND.
+type httpLogFilter struct {
+	// From and To define the range of request_date, the From is
+	// inclusive and To is exclusive.
+	From time.Time
+	To   time.Time
+
+	Backend   string
+	Frontend  string
+	Server    string
+	Method    string
+	URLPrefix string
+	ClientIP  string
+	TermState string
+
+	// cursor contains the position of the last record in the previous
+	// page.
+	cursor httpLogCursor
+
+	Status    int32
+	StatusMin int32
+	StatusMax int32
+
+	Limit int
+}
+
+// httpLogCursor define the position of record in the list ordered by
+// request_date and id in descending order, served by index
+// "http_log_date_id_idx".
+// The id is the unique identifier of log in database, used only as
+// tiebreaker for the logs with the same request_date, so its does not
+// need to be in the same order as request_date.
+//
+// In Postgresql, the logs stored before the column id added have NULL id
+// until backfilled by "haminer migrate -backfill-id".
+// The NULL id is ordered before the non-NULL id, the default for
+// descending order in Postgresql, and the logs with NULL id and the same
+// request_date as the cursor may be skipped.
+// In SQLite, the column id is alias of rowid and never NULL.
+type httpLogCursor struct {
+	RequestDate time.Time
+	ID          sql.NullInt64
+}
+
+// httpLogPage contains the result of search.
+type httpLogPage struct {
+	// Next contains the cursor to fetch the next page, empty if no more
+	// record.
+	Next string    `json:"next,omitempty"`
+	List []HTTPLog `json:"list"`
+}
+
+// parseHTTPLogFilter parse the filter from URL query parameters.
+func parseHTTPLogFilter(q url.Values) (filter httpLogFilter, err error) {
+	filter = httpLogFilter{
+		Backend:   q.Get(`backend`),
+		Frontend:  q.Get(`frontend`),
+		Server:    q.Get(`server`),
+		Method:    q.Get(`method`),
+		URLPrefix: q.Get(`url_prefix`),
+		ClientIP:  q.Get(`client_ip`),
+		TermState: q.Get(`term_state`),
+		Limit:     defSearchLimit,
+	}
+
+	var v string
+
+	v = q.Get(`from`)
+	if len(v) != 0 {
+		filter.From, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, liberrors.InvalidInput(`from`)
+		}
+	}
+	v = q.Get(`to`)
+	if len(v) != 0 {
+		filter.To, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, liberrors.InvalidInput(`to`)
+		}
+	}
+
+	var (
+		name   string
+		status *int32
+	)
+	for name, status = range map[string]*int32{
+		`status`:     &filter.Status,
+		`status_min`: &filter.StatusMin,
+		`status_max`: &filter.StatusMax,
+	} {
+		v = q.Get(name)
+		if len(v) == 0 {
+			continue
+		}
+
+		var n int64
+
+		n, err = strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return filter, liberrors.InvalidInput(name)
+		}
+		*status = int32(n)
+	}
+
+	v = q.Get(`limit`)
+	if len(v) != 0 {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil || filter.Limit <= 0 {
+			return filter, liberrors.InvalidInput(`limit`)
+		}
+		if filter.Limit > maxSearchLimit {
+			filter.Limit = maxSearchLimit
+		}
+	}
+
+	v = q.Get(`cursor`)
+	if len(v) != 0 {
+		filter.cursor, err = parseHTTPLogCursor(v)
+		if err != nil {
+			return filter, liberrors.InvalidInput(`cursor`)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseHTTPLogCursor decode the cursor from string generated by
+// [httpLogCursor.String].
+func parseHTTPLogCursor(v string) (cursor httpLogCursor, err error) {
+	var raw []byte
+
+	raw, err = base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return cursor, err
+	}
+
+	var fields = strings.SplitN(string(raw), `|`, 2)
+	if len(fields) != 2 {
+		return cursor, fmt.Errorf(`invalid cursor %q`, v)
+	}
+
+	var n int64
+
+	n, err = strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return cursor, err
+	}
+	cursor.RequestDate = time.Unix(0, n).UTC()
+
+	if len(fields[1]) == 0 {
+		// The id is NULL.
+		return cursor, nil
+	}
+
+	cursor.ID.Int64, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return cursor, err
+	}
+	cursor.ID.Valid = true
+
+	return cursor, nil
+}
+
+// String encode the cursor into opaque string.
+// The NULL id is encoded as empty string.
+func (cursor httpLogCursor) String() string {
+	var raw = fmt.Sprintf(`%d|`, cursor.RequestDate.UnixNano())
+	if cursor.ID.Valid {
+		raw += strconv.FormatInt(cursor.ID.Int64, 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// isEmpty return true if no filter is set.
+// The Limit is not considered as filter.
+func (filter *httpLogFilter) isEmpty() bool {
+	var empty = httpLogFilter{
+		Limit: filter.Limit,
+	}
+	return *filter == empty
+}
+
+// match return true if the halog match with all the filters.
+// The cursor is ignored.
+func (filter *httpLogFilter) match(halog *HTTPLog) bool {
+	if !filter.From.IsZero() && halog.RequestDate.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !halog.RequestDate.Before(filter.To) {
+		return false
+	}
+
+	var listEqual = []struct {
+		exp string
+		got string
+	}{
+		{filter.Backend, halog.BackendName},
+		{filter.Frontend, halog.FrontendName},
+		{filter.Server, halog.ServerName},
+		{filter.Method, halog.HTTPMethod},
+		{filter.ClientIP, halog.ClientIP},
+		{filter.TermState, halog.TerminationState},
+	}
+	for _, eq := range listEqual {
+		if len(eq.exp) != 0 && eq.exp != eq.got {
+			return false
+		}
+	}
+
+	if !strings.HasPrefix(halog.HTTPURL, filter.URLPrefix) {
+		return false
+	}
+	if filter.Status != 0 && halog.StatusCode != filter.Status {
+		return false
+	}
+	if filter.StatusMin != 0 && halog.StatusCode < filter.StatusMin {
+		return false
+	}
+	if filter.StatusMax != 0 && halog.StatusCode > filter.StatusMax {
+		return false
+	}
+	return true
+}
+
+// where generate the SQL WHERE conditions for the driver and bind its
+// values into meta.
+// The time is converted to UTC, since the SQLite compare the request_date
+// as text.
+func (filter *httpLogFilter) where(driver string, meta *libsql.Meta) string {
+	var (
+		conds  []string
+		holder = func(val any) string {
+			var n = meta.BindWhere(``, val)
+			return meta.ListHolder[n-1]
+		}
+	)
+
+	if !filter.From.IsZero() {
+		conds = append(conds, `request_date >= `+holder(filter.From.UTC()))
+	}
+	if !filter.To.IsZero() {
+		conds = append(conds, `request_date < `+holder(filter.To.UTC()))
+	}
+
+	var listEqual = []struct {
+		col string
+		val string
+	}{
+		{`backend_name`, filter.Backend},
+		{`frontend_name`, filter.Frontend},
+		{`server_name`, filter.Server},
+		{`http_method`, filter.Method},
+		{`client_ip`, filter.ClientIP},
+		{`termination_state`, filter.TermState},
+	}
+	for _, eq := range listEqual {
+		if len(eq.val) != 0 {
+			conds = append(conds, eq.col+` = `+holder(eq.val))
+		}
+	}
+
+	if len(filter.URLPrefix) != 0 && driver == driverNameSqlite {
+		// The LIKE in SQLite is case insensitive.
+		conds = append(conds, `instr(http_url, `+holder(filter.URLPrefix)+`) = 1`)
+	} else if len(filter.URLPrefix) != 0 {
+		var prefix = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).
+			Replace(filter.URLPrefix)
+		conds = append(conds, `http_url LIKE `+holder(prefix+`%`)+` ESCAPE '\'`)
+	}
+	if filter.Status != 0 {
+		conds = append(conds, `status_code = `+holder(filter.Status))
+	}
+	if filter.StatusMin != 0 {
+		conds = append(conds, `status_code >= `+holder(filter.StatusMin))
+	}
+	if filter.StatusMax != 0 {
+		conds = append(conds, `status_code <= `+holder(filter.StatusMax))
+	}
+
+	if !filter.cursor.RequestDate.IsZero() {
+		conds = append(conds, filter.cursor.where(holder))
+	}
+
+	if len(conds) == 0 {
+		return ``
+	}
+	return ` WHERE ` + strings.Join(conds, ` AND `)
+}
+
+// where generate the SQL condition to select the records after the
+// cursor.
+// The rows with NULL id and the same request_date are ordered before the
+// non-NULL id, so they are selected only if the cursor id is NULL.
+func (cursor httpLogCursor) where(holder func(val any) string) string {
+	if cursor.ID.Valid {
+		return fmt.Sprintf(`(request_date, id) < (%s, %s)`,
+			holder(cursor.RequestDate.UTC()), holder(cursor.ID.Int64))
+	}
+	var date = cursor.RequestDate.UTC()
+	return fmt.Sprintf(`(request_date < %s OR (request_date = %s AND id IS NOT NULL))`,
+		holder(date), holder(date))
+}
+
+// searchHTTPLog fetch the HTTPLog records from database that match with
+// the filter, ordered by the newest request.
+func searchHTTPLog(dbc libsql.Session, driver string, filter httpLogFilter) (page httpLogPage, err error) {
+	var (
+		logp    = `searchHTTPLog`
+		httpLog = HTTPLog{}
+		meta    = httpLog.generateSQLMeta(driver, libsql.DMLKindSelect)
+		where   = filter.where(driver, meta)
+
+		id     sql.NullInt64
+		lastID sql.NullInt64
+	)
+
+	var q = fmt.Sprintf(`SELECT id, %s FROM %s%s`+
+		` ORDER BY request_date DESC, id DESC`+
+		` LIMIT %d;`,
+		meta.Names(), tableNameHTTPLog, where, filter.Limit+1)
+
+	var rows *sql.Rows
+
+	rows, err = dbc.Query(q, meta.ListWhereValue...)
+	if err != nil {
+		return page, fmt.Errorf(`%s: %w`, logp, err)
+	}
+	defer rows.Close()
+
+	page.List = make([]HTTPLog, 0, filter.Limit)
+
+	var dest = append([]any{&id}, meta.ListValue...)
+
+	for rows.Next() {
+		err = rows.Scan(dest...)
+		if err != nil {
+			return page, fmt.Errorf(`%s: %w`, logp, err)
+		}
+		if len(page.List) < filter.Limit {
+			lastID = id
+		}
+		page.List = append(page.List, httpLog)
+	}
+	err = rows.Err()
+	if err != nil {
+		return page, fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	if len(page.List) > filter.Limit {
+		page.List = page.List[:filter.Limit]
+
+		var last = page.List[len(page.List)-1]
+
+		page.Next = httpLogCursor{
+			RequestDate: last.RequestDate,
+			ID:          lastID,
+		}.String()
+	}
+
+	return page, nil
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"database/sql"
+	"net/url"
+	"testing"
+	"time"
+
+	libsql "git.sr.ht/~shulhan/pakakeh.go/lib/sql"
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestParseHTTPLogFilter(t *testing.T) {
+	type testCase struct {
+		desc   string
+		query  string
+		expErr string
+		exp    httpLogFilter
+	}
+
+	var cases = []testCase{{
+		desc:  `With empty query`,
+		exp:   httpLogFilter{Limit: defSearchLimit},
+		query: ``,
+	}, {
+		desc:  `With all fields`,
+		query: `from=2026-01-18T00:00:00Z&to=2026-01-19T00:00:00Z&backend=be&frontend=fe&server=srv&method=GET&url_prefix=/api&client_ip=127.0.0.1&term_state=----&status=200&status_min=200&status_max=299&limit=5000`,
+		exp: httpLogFilter{
+			From:      time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+			To:        time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC),
+			Backend:   `be`,
+			Frontend:  `fe`,
+			Server:    `srv`,
+			Method:    `GET`,
+			URLPrefix: `/api`,
+			ClientIP:  `127.0.0.1`,
+			TermState: `----`,
+			Status:    200,
+			StatusMin: 200,
+			StatusMax: 299,
+			Limit:     maxSearchLimit,
+		},
+	}, {
+		desc:   `With invalid from`,
+		query:  `from=2026-01-18`,
+		expErr: `invalid input: from`,
+	}, {
+		desc:   `With invalid status`,
+		query:  `status=2xx`,
+		expErr: `invalid input: status`,
+	}, {
+		desc:   `With invalid limit`,
+		query:  `limit=0`,
+		expErr: `invalid input: limit`,
+	}, {
+		desc:   `With invalid cursor`,
+		query:  `cursor=abc`,
+		expErr: `invalid input: cursor`,
+	}}
+
+	var (
+		c   testCase
+		q   url.Values
+		got httpLogFilter
+		err error
+	)
+	for _, c = range cases {
+		q, err = url.ParseQuery(c.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err = parseHTTPLogFilter(q)
+		if err != nil {
+			test.Assert(t, c.desc, c.expErr, err.Error())
+			continue
+		}
+		test.Assert(t, c.desc, c.exp, got)
+	}
+}
+
+func TestHTTPLogCursor(t *testing.T) {
+	var (
+		exp = httpLogCursor{
+			RequestDate: time.Date(2026, 1, 18, 5, 8, 28, 123, time.UTC),
+			ID:          sql.NullInt64{Int64: 40912, Valid: true},
+		}
+
+		got httpLogCursor
+		err error
+	)
+
+	got, err = parseHTTPLogCursor(exp.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `cursor`, exp, got)
+
+	// The cursor with NULL id.
+	exp.ID = sql.NullInt64{}
+
+	got, err = parseHTTPLogCursor(exp.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `cursor with NULL id`, exp, got)
+}
+
+func TestHTTPLogFilter_where(t *testing.T) {
+	var (
+		cursor = httpLogCursor{
+			RequestDate: time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC),
+			ID:          sql.NullInt64{Int64: 8080, Valid: true},
+		}
+		filter = httpLogFilter{
+			Backend:   `be`,
+			URLPrefix: `/a_b%`,
+			StatusMin: 500,
+			cursor:    cursor,
+		}
+		meta = libsql.NewMeta(libsql.DriverNamePostgres, libsql.DMLKindSelect)
+	)
+
+	var exp = ` WHERE backend_name = $1` +
+		` AND http_url LIKE $2 ESCAPE '\'` +
+		` AND status_code >= $3` +
+		` AND (request_date, id) < ($4, $5)`
+
+	test.Assert(t, `where`, exp, filter.where(libsql.DriverNamePostgres, meta))
+
+	var expValues = []any{`be`, `/a\_b\%%`, int32(500),
+		cursor.RequestDate, cursor.ID.Int64}
+
+	test.Assert(t, `ListWhereValue`, expValues, meta.ListWhereValue)
+
+	// The cursor with NULL id.
+	filter = httpLogFilter{
+		cursor: httpLogCursor{
+			RequestDate: cursor.RequestDate,
+		},
+	}
+	meta = libsql.NewMeta(libsql.DriverNamePostgres, libsql.DMLKindSelect)
+
+	exp = ` WHERE (request_date < $1 OR (request_date = $2 AND id IS NOT NULL))`
+
+	test.Assert(t, `where NULL id`, exp, filter.where(libsql.DriverNamePostgres, meta))
+}
+
+func TestHTTPLogFilter_match(t *testing.T) {
+	type testCase struct {
+		desc  string
+		query string
+		exp   bool
+	}
+
+	var halog = &HTTPLog{
+		RequestDate:      time.Date(2026, 1, 18, 5, 8, 28, 0, time.UTC),
+		ClientIP:         `10.0.0.1`,
+		FrontendName:     `fe-http`,
+		BackendName:      `api`,
+		ServerName:       `api-1`,
+		HTTPMethod:       `POST`,
+		HTTPURL:          `/v1/users`,
+		TerminationState: `SH--`,
+		StatusCode:       503,
+	}
+
+	var cases = []testCase{{
+		desc: `With empty filter`,
+		exp:  true,
+	}, {
+		desc:  `With backend and status_min`,
+		query: `backend=api&status_min=500`,
+		exp:   true,
+	}, {
+		desc:  `With different backend`,
+		query: `backend=web`,
+	}, {
+		desc:  `With status_max`,
+		query: `status_max=499`,
+	}, {
+		desc:  `With url_prefix`,
+		query: `url_prefix=/v1/&client_ip=10.0.0.1&term_state=SH--`,
+		exp:   true,
+	}, {
+		desc:  `With different url_prefix`,
+		query: `url_prefix=/v2/`,
+	}, {
+		desc:  `With time range`,
+		query: `from=2026-01-18T05:00:00Z&to=2026-01-18T05:08:28Z`,
+	}}
+
+	var (
+		c      testCase
+		q      url.Values
+		filter httpLogFilter
+		err    error
+	)
+	for _, c = range cases {
+		q, err = url.ParseQuery(c.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		filter, err = parseHTTPLogFilter(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test.Assert(t, c.desc+`: isEmpty`, len(c.query) == 0, filter.isEmpty())
+		test.Assert(t, c.desc, c.exp, filter.match(halog))
+	}
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defTailerQueueSize the number of logs queued for each subscriber
+	// of apiLogTail.
+	defTailerQueueSize = 512
+
+	// defTailerMaxMissed the number of logs dropped, without being
+	// notified, before the subscriber evicted.
+	defTailerMaxMissed = 8192
+
+	// defTailerPingInterval the interval to check if the subscriber
+	// still connected, and notify the missed logs.
+	defTailerPingInterval = 15 * time.Second
+)
+
+// tailLog contains the log published to the subscribers of HTTP API
+// apiLogTail.
+type tailLog struct {
+	// halog the parsed raw log, nil if raw cannot be parsed.
+	halog *HTTPLog
+
+	raw string
+}
+
+// newTailLog create new tailLog from the raw log and its parsed HTTPLog.
+// The raw must be copied from the packet before its parsed, since the
+// parser modify the packet in place.
+// The halog is copied, so any changes on the original HTTPLog after this
+// call does not affect the published log.
+func newTailLog(raw string, halog *HTTPLog) (tlog *tailLog) {
+	tlog = &tailLog{
+		raw: raw,
+	}
+	if halog != nil {
+		var dup = *halog
+		tlog.halog = &dup
+	}
+	return tlog
+}
+
+// processQuery normalize the HTTP query in the tailLog, including in its
+// raw log, using the same queryProcessor applied to the forwarded logs,
+// so the removed and redacted parameters are not published.
+func (tlog *tailLog) processQuery(qp *queryProcessor) {
+	if qp == nil || tlog.halog == nil || len(tlog.halog.HTTPQuery) == 0 {
+		return
+	}
+
+	var (
+		orig = tlog.halog.HTTPQuery
+		newq = qp.process(orig)
+	)
+	if newq == orig {
+		return
+	}
+	tlog.halog.HTTPQuery = newq
+
+	var repl string
+	if len(newq) != 0 {
+		repl = `?` + newq
+	}
+	tlog.raw = strings.Replace(tlog.raw, `?`+orig+` `, repl+` `, 1)
+}
+
+// omitCookie remove the request and response cookies that are not masked
+// from the tailLog, including in its raw log.
+// The cookie in the raw log is replaced with "-", the same as HAProxy log
+// without cookie.
+// It will return false if the raw log cannot be parsed, so the cookies
+// cannot be removed.
+func (tlog *tailLog) omitCookie() bool {
+	if tlog.halog == nil {
+		return false
+	}
+
+	var (
+		cookieReq = `-`
+		cookieRes = `-`
+	)
+	if tlog.halog.CookieRequest == redactedValue {
+		cookieReq = redactedValue
+	}
+	if tlog.halog.CookieResponse == redactedValue {
+		cookieRes = redactedValue
+	}
+	tlog.replaceRawCookie(cookieReq, cookieRes)
+
+	tlog.halog.omitCookie()
+	return true
+}
+
+// replaceRawCookie replace the request and response cookies in the raw
+// log with cookieReq and cookieRes.
+// The cookies are replaced along with the termination state that follow
+// them, so the same value in other fields, or the same value in both
+// cookies, is not replaced.
+func (tlog *tailLog) replaceRawCookie(cookieReq, cookieRes string) {
+	var (
+		halog  = tlog.halog
+		oldVal = ` ` + halog.CookieRequest + ` ` + halog.CookieResponse + ` ` + halog.TerminationState + ` `
+		newVal = ` ` + cookieReq + ` ` + cookieRes + ` ` + halog.TerminationState + ` `
+	)
+	tlog.raw = strings.Replace(tlog.raw, oldVal, newVal, 1)
+}
+
+// format return the tailLog as JSON of HTTPLog if isJSON is true, or
+// the raw log otherwise.
+func (tlog *tailLog) format(isJSON bool) (data string, err error) {
+	if !isJSON || tlog.halog == nil {
+		return tlog.raw, nil
+	}
+
+	var raw []byte
+
+	raw, err = json.Marshal(tlog.halog)
+	if err != nil {
+		return ``, err
+	}
+	return string(raw), nil
+}
+
+// tailer contains the queue and statistics of each subscriber of HTTP API
+// apiLogTail.
+type tailer struct {
+	connectedAt time.Time
+
+	logq chan *tailLog
+
+	remoteAddr string
+
+	id int64
+
+	// sent the number of logs received by subscriber.
+	sent atomic.Int64
+
+	// dropped the total number of logs dropped because the logq is
+	// full.
+	dropped atomic.Int64
+
+	// missed the number of logs dropped since the last time subscriber
+	// notified.
+	missed atomic.Int64
+}
+
+// tailerStats contains the statistics of tailer for HTTP API
+// apiLogTailStats.
+type tailerStats struct {
+	ConnectedAt time.Time `json:"connected_at"`
+	RemoteAddr  string    `json:"remote_addr"`
+	ID          int64     `json:"id"`
+	Sent        int64     `json:"sent"`
+	Dropped     int64     `json:"dropped"`
+	Queued      int       `json:"queued"`
+}
+
+// tailerSummary contains the statistics of all tailers.
+type tailerSummary struct {
+	List []tailerStats `json:"list"`
+
+	// Evicted the number of subscribers that has been evicted because
+	// they are too slow.
+	Evicted int64 `json:"evicted"`
+}
+
+func newTailer(id int64, remoteAddr string) (t *tailer) {
+	t = &tailer{
+		id:          id,
+		remoteAddr:  remoteAddr,
+		connectedAt: time.Now().UTC(),
+		logq:        make(chan *tailLog, defTailerQueueSize),
+	}
+	return t
+}
+
+// push the tlog into queue without blocking.
+// If the queue is full, the tlog is dropped and it will return false if
+// the number of missed logs reach the defTailerMaxMissed.
+func (t *tailer) push(tlog *tailLog) bool {
+	select {
+	case t.logq <- tlog:
+		return true
+	default:
+	}
+	t.dropped.Add(1)
+	return t.missed.Add(1) < defTailerMaxMissed
+}
+
+func (t *tailer) stats() tailerStats {
+	return tailerStats{
+		ConnectedAt: t.connectedAt,
+		RemoteAddr:  t.remoteAddr,
+		ID:          t.id,
+		Sent:        t.sent.Load(),
+		Dropped:     t.dropped.Load(),
+		Queued:      len(t.logq),
+	}
+}
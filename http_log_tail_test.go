@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestTailer_push(t *testing.T) {
+	var (
+		tl   = newTailer(1, `127.0.0.1:4000`)
+		tlog = &tailLog{raw: `log`}
+		x    int
+	)
+
+	for x = 0; x < defTailerQueueSize; x++ {
+		test.Assert(t, `push`, true, tl.push(tlog))
+	}
+
+	// The queue is full, the next logs are dropped.
+	test.Assert(t, `push on full`, true, tl.push(tlog))
+	test.Assert(t, `dropped`, int64(1), tl.dropped.Load())
+	test.Assert(t, `missed`, int64(1), tl.missed.Load())
+
+	// Consuming the queue does not reset the missed counter, only
+	// notifying the subscriber does.
+	<-tl.logq
+	test.Assert(t, `push after consume`, true, tl.push(tlog))
+	test.Assert(t, `missed`, int64(1), tl.missed.Swap(0))
+
+	for x = 1; x < defTailerMaxMissed; x++ {
+		test.Assert(t, `push until evicted`, true, tl.push(tlog))
+	}
+	test.Assert(t, `push evicted`, false, tl.push(tlog))
+
+	var exp = tailerStats{
+		ConnectedAt: tl.connectedAt,
+		RemoteAddr:  `127.0.0.1:4000`,
+		ID:          1,
+		Dropped:     defTailerMaxMissed + 1,
+		Queued:      defTailerQueueSize,
+	}
+	test.Assert(t, `stats`, exp, tl.stats())
+}
+
+func TestHTTPServer_logPublisher(t *testing.T) {
+	var (
+		total = defTailerQueueSize + defTailerMaxMissed
+		httpd = &httpServer{
+			rawlogq: make(chan *tailLog, total),
+			tailer:  make(map[int64]*tailer),
+		}
+		slow = httpd.registerTailer(`127.0.0.1:4000`)
+		x    int
+	)
+
+	for x = 0; x < total; x++ {
+		httpd.rawlogq <- &tailLog{raw: `log`}
+	}
+	close(httpd.rawlogq)
+
+	httpd.logPublisher()
+
+	test.Assert(t, `tailerEvicted`, int64(1), httpd.tailerEvicted)
+	test.Assert(t, `tailer`, 0, len(httpd.tailer))
+
+	var n int
+	for range slow.logq {
+		n++
+	}
+	test.Assert(t, `queued before evicted`, defTailerQueueSize, n)
+}
+
+func TestTailLog_processQuery(t *testing.T) {
+	type testCase struct {
+		desc     string
+		query    string
+		expRaw   string
+		expQuery string
+	}
+
+	var (
+		qp    = newQueryProcessor(nil, []string{`session`}, nil)
+		cases = []testCase{{
+			desc:     `With redacted and denied parameters`,
+			query:    `token=abc&session=1&page=2`,
+			expRaw:   `fe be/srv 200 "GET /login?page=2&token=xxxxx HTTP/1.1"`,
+			expQuery: `page=2&token=xxxxx`,
+		}, {
+			desc:   `With all parameters removed`,
+			query:  `session=1`,
+			expRaw: `fe be/srv 200 "GET /login HTTP/1.1"`,
+		}, {
+			desc:     `Without changes`,
+			query:    `page=2`,
+			expRaw:   `fe be/srv 200 "GET /login?page=2 HTTP/1.1"`,
+			expQuery: `page=2`,
+		}}
+
+		c testCase
+	)
+	for _, c = range cases {
+		var (
+			packet = []byte(`fe be/srv 200 "GET /login?` + c.query + ` HTTP/1.1"`)
+			halog  = &HTTPLog{
+				HTTPURL:   `/login`,
+				HTTPQuery: c.query,
+			}
+			tlog = newTailLog(string(packet), halog)
+		)
+
+		tlog.processQuery(qp)
+
+		test.Assert(t, c.desc+`: raw`, c.expRaw, tlog.raw)
+		test.Assert(t, c.desc+`: HTTPQuery`, c.expQuery, tlog.halog.HTTPQuery)
+	}
+}
+
+func TestTailLog_omitCookie(t *testing.T) {
+	type testCase struct {
+		desc      string
+		cookieReq string
+		cookieRes string
+		expRaw    string
+	}
+
+	var (
+		prefix = `<134>Mar 17 05:08:28 haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be/srv 1/2/3/4/5 200 149 `
+		suffix = ` ---- 1/1/2/3/4 5/6 "GET / HTTP/1.1"`
+
+		cases = []testCase{{
+			desc:      `With cookies`,
+			cookieReq: `sid=abc`,
+			cookieRes: `sid=def`,
+			expRaw:    prefix + `- -` + suffix,
+		}, {
+			desc:      `With masked cookie`,
+			cookieReq: redactedValue,
+			cookieRes: `sid=def`,
+			expRaw:    prefix + redactedValue + ` -` + suffix,
+		}, {
+			desc:      `Without cookies`,
+			cookieReq: `-`,
+			cookieRes: `-`,
+			expRaw:    prefix + `- -` + suffix,
+		}}
+
+		c testCase
+	)
+	for _, c = range cases {
+		var (
+			raw   = prefix + c.cookieReq + ` ` + c.cookieRes + suffix
+			halog = ParseUDPPacket([]byte(raw), nil)
+			tlog  = newTailLog(raw, halog)
+		)
+
+		test.Assert(t, c.desc+`: omitCookie`, true, tlog.omitCookie())
+		test.Assert(t, c.desc+`: raw`, c.expRaw, tlog.raw)
+
+		var expCookieReq string
+		if c.cookieReq == redactedValue {
+			expCookieReq = redactedValue
+		}
+		test.Assert(t, c.desc+`: CookieRequest`, expCookieReq, tlog.halog.CookieRequest)
+		test.Assert(t, c.desc+`: CookieResponse`, ``, tlog.halog.CookieResponse)
+	}
+
+	// The raw log that cannot be parsed is not published.
+	var tlog = newTailLog(`invalid`, nil)
+	test.Assert(t, `omitCookie on invalid`, false, tlog.omitCookie())
+}
@@ -5,7 +5,9 @@
 package haminer
 
 import (
+	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
@@ -24,6 +26,8 @@ func TestParseUDPPacket(t *testing.T) {
 
 	var listCase = []string{
 		`http_log_0000`,
+		`http_log_0001_nosrv`,
+		`http_log_0002_badreq`,
 	}
 
 	var (
@@ -44,3 +48,109 @@ func TestParseUDPPacket(t *testing.T) {
 		test.Assert(t, tag, exp, string(got))
 	}
 }
+
+func TestSQLJSONMap(t *testing.T) {
+	type testCase struct {
+		desc string
+		in   map[string]string
+		exp  any
+	}
+
+	var cases = []testCase{{
+		desc: `With nil map`,
+	}, {
+		desc: `With headers`,
+		in: map[string]string{
+			`host`:       `api.example.com`,
+			`user_agent`: `curl/8.0`,
+		},
+		exp: `{"host":"api.example.com","user_agent":"curl/8.0"}`,
+	}}
+
+	var (
+		c   testCase
+		got any
+		err error
+	)
+	for _, c = range cases {
+		t.Log(c.desc)
+
+		got, err = sqlJSONMap(c.in).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.Assert(t, `Value`, c.exp, got)
+
+		var jmap sqlJSONMap
+
+		err = jmap.Scan(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.Assert(t, `Scan`, sqlJSONMap(c.in), jmap)
+	}
+}
+
+func TestHTTPLog_omitCookie(t *testing.T) {
+	var (
+		halog = &HTTPLog{
+			StatusCode:     200,
+			CookieRequest:  `sid=abc`,
+			CookieResponse: redactedValue,
+		}
+		got []byte
+		err error
+	)
+
+	halog.omitCookie()
+
+	got, err = json.Marshal(halog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var exp = `{"request_date":"0001-01-01T00:00:00Z","client_ip":"","client_label":"","client_host":"","geo_country":"","geo_city":"","as_org":"","asn":0,"ua_browser":"","ua_os":"","ua_device":"","frontend_name":"","backend_name":"","server_name":"","http_proto":"","http_method":"","http_url":"","http_query":"","cookie_response":"` + redactedValue + `","termination_state":"","bytes_read":0,"sample_weight":0,"status_code":200,"client_port":0,"time_request":0,"time_wait":0,"time_connect":0,"time_response":0,"time_all":0,"conn_active":0,"conn_frontend":0,"conn_backend":0,"conn_server":0,"retries":0,"server_queue":0,"backend_queue":0,"is_bot":false,"is_error":false}`
+	test.Assert(t, `JSON`, exp, string(got))
+}
+
+func TestHTTPLog_writeIlp_httpQuery(t *testing.T) {
+	type testCase struct {
+		desc      string
+		query     string
+		expInTags string
+	}
+
+	var (
+		cases = []testCase{{
+			desc:      `With query`,
+			query:     `a=1&b=x y,z`,
+			expInTags: `,http_query=a\=1&b\=x\ y\,z `,
+		}, {
+			desc: `Without query`,
+		}}
+
+		c testCase
+	)
+	for _, c = range cases {
+		var (
+			halog = &HTTPLog{
+				tagHTTPQuery: c.query,
+			}
+			ilp bytes.Buffer
+			err error
+		)
+
+		err = halog.writeIlp(&ilp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got = ilp.String()
+
+		if len(c.expInTags) == 0 {
+			test.Assert(t, c.desc, false, strings.Contains(got, `http_query`))
+			continue
+		}
+		test.Assert(t, c.desc, true, strings.Contains(got, c.expInTags))
+	}
+}
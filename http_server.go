@@ -4,30 +4,59 @@
 package haminer
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	liberrors "git.sr.ht/~shulhan/pakakeh.go/lib/errors"
 	libhttp "git.sr.ht/~shulhan/pakakeh.go/lib/http"
 	"git.sr.ht/~shulhan/pakakeh.go/lib/memfs"
 	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
+	libsql "git.sr.ht/~shulhan/pakakeh.go/lib/sql"
 )
 
 const (
-	pathAPILogTail = `/api/log/tail`
+	pathAPIDashboard    = `/api/dashboard`
+	pathAPILogSearch    = `/api/log/search`
+	pathAPILogTail      = `/api/log/tail`
+	pathAPILogTailStats = `/api/log/tail/stats`
 )
 
+// defDashboardInterval the interval to publish the dashboard statistics.
+const defDashboardInterval = time.Second
+
 var memfsWUI *memfs.MemFS
 
 type httpServer struct {
 	*libhttp.Server
 
+	// dbc the database client where the logs stored, used by HTTP API
+	// apiLogSearch.
+	// If its nil, the search API response with error.
+	// It may be replaced when the configuration reloaded.
+	dbc atomic.Pointer[libsql.Client]
+
+	// dash aggregate the logs for HTTP API apiDashboard.
+	dash *dashboard
+
 	// rawlogq channel that receive raw log to be published by HTTP API
 	// apiLogTail.
-	rawlogq chan string
+	rawlogq chan *tailLog
+
+	tailer        map[int64]*tailer
+	tailerIdx     int64
+	tailerEvicted int64
+	tailerMtx     sync.Mutex
 
-	tailer    map[int64]chan string
-	tailerIdx int64
-	tailerMtx sync.Mutex
+	// showCookie if true, the cookies that are not masked are
+	// included in the HTTP API response.
+	showCookie bool
 }
 
 func newHTTPServer(cfg *Config) (httpd *httpServer, err error) {
@@ -38,13 +67,29 @@ func newHTTPServer(cfg *Config) (httpd *httpServer, err error) {
 	}
 
 	httpd = &httpServer{
-		rawlogq: make(chan string, 512),
-		tailer:  make(map[int64]chan string),
+		dash:       &dashboard{},
+		rawlogq:    make(chan *tailLog, 512),
+		tailer:     make(map[int64]*tailer),
+		showCookie: cfg.WuiShowCookie,
 	}
 
 	var opts = libhttp.ServerOptions{
 		Memfs:   memfsWUI,
 		Address: cfg.WuiAddress,
+		Conn:    &http.Server{},
+	}
+
+	if len(cfg.WuiTLSCert) != 0 {
+		var cert tls.Certificate
+
+		cert, err = tls.LoadX509KeyPair(cfg.WuiTLSCert, cfg.WuiTLSKey)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, logp, err)
+		}
+		opts.Conn.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
 	}
 
 	httpd.Server, err = libhttp.NewServer(opts)
@@ -52,6 +97,10 @@ func newHTTPServer(cfg *Config) (httpd *httpServer, err error) {
 		return nil, fmt.Errorf(`%s: %w`, logp, err)
 	}
 
+	// Wrap the server handler to check the source address and
+	// authorization before serving any resources.
+	httpd.Server.Server.Handler = newWuiGuard(cfg, httpd.Server)
+
 	err = httpd.registerEndpoints()
 	if err != nil {
 		return nil, fmt.Errorf(`%s: %w`, logp, err)
@@ -60,15 +109,28 @@ func newHTTPServer(cfg *Config) (httpd *httpServer, err error) {
 	return httpd, nil
 }
 
+// logPublisher publish the log from rawlogq to all tailers.
+// The log is delivered without blocking, so one slow tailer does not
+// block the others.
+// The tailer that miss too many logs is evicted.
 func (httpd *httpServer) logPublisher() {
 	var (
-		rawlog string
-		tailer chan string
+		logp = `logPublisher`
+
+		tlog *tailLog
+		t    *tailer
 	)
-	for rawlog = range httpd.rawlogq {
+	for tlog = range httpd.rawlogq {
 		httpd.tailerMtx.Lock()
-		for _, tailer = range httpd.tailer {
-			tailer <- rawlog
+		for _, t = range httpd.tailer {
+			if t.push(tlog) {
+				continue
+			}
+			mlog.Errf(`%s: tailer %d from %s evicted, too slow`,
+				logp, t.id, t.remoteAddr)
+			close(t.logq)
+			delete(httpd.tailer, t.id)
+			httpd.tailerEvicted++
 		}
 		httpd.tailerMtx.Unlock()
 	}
@@ -77,6 +139,36 @@ func (httpd *httpServer) logPublisher() {
 func (httpd *httpServer) registerEndpoints() (err error) {
 	var logp = `registerEndpoints`
 
+	err = httpd.RegisterEndpoint(libhttp.Endpoint{
+		Method:       libhttp.RequestMethodGet,
+		Path:         pathAPILogSearch,
+		RequestType:  libhttp.RequestTypeQuery,
+		ResponseType: libhttp.ResponseTypeJSON,
+		Call:         httpd.apiLogSearch,
+	})
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = httpd.RegisterEndpoint(libhttp.Endpoint{
+		Method:       libhttp.RequestMethodGet,
+		Path:         pathAPILogTailStats,
+		RequestType:  libhttp.RequestTypeNone,
+		ResponseType: libhttp.ResponseTypeJSON,
+		Call:         httpd.apiLogTailStats,
+	})
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = httpd.RegisterSSE(libhttp.SSEEndpoint{
+		Call: httpd.apiDashboard,
+		Path: pathAPIDashboard,
+	})
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
 	err = httpd.RegisterSSE(libhttp.SSEEndpoint{
 		Call: httpd.apiLogTail,
 		Path: pathAPILogTail,
@@ -87,7 +179,7 @@ func (httpd *httpServer) registerEndpoints() (err error) {
 	return nil
 }
 
-func (httpd *httpServer) registerTailer() (idx int64, tailer chan string) {
+func (httpd *httpServer) registerTailer(remoteAddr string) (t *tailer) {
 	var ok bool
 
 	httpd.tailerMtx.Lock()
@@ -100,26 +192,25 @@ func (httpd *httpServer) registerTailer() (idx int64, tailer chan string) {
 		}
 		httpd.tailerIdx++
 	}
-	idx = httpd.tailerIdx
-	tailer = make(chan string, 512)
-	httpd.tailer[idx] = tailer
+	t = newTailer(httpd.tailerIdx, remoteAddr)
+	httpd.tailer[t.id] = t
 
 	httpd.tailerMtx.Unlock()
 
-	return idx, tailer
+	return t
 }
 
 func (httpd *httpServer) unregisterTailer(idx int64) {
 	var (
-		tailer chan string
-		ok     bool
+		t  *tailer
+		ok bool
 	)
 
 	httpd.tailerMtx.Lock()
 
-	tailer, ok = httpd.tailer[idx]
+	t, ok = httpd.tailer[idx]
 	if ok {
-		close(tailer)
+		close(t.logq)
 		delete(httpd.tailer, idx)
 	}
 
@@ -129,7 +220,13 @@ func (httpd *httpServer) unregisterTailer(idx int64) {
 func (httpd *httpServer) start() (err error) {
 	var logp = `start`
 
-	mlog.Outf(`%s: starting HTTP server at http://%s`, logp, httpd.Options.Address)
+	var scheme = `http`
+	if httpd.Server.Server.TLSConfig != nil {
+		scheme = `https`
+	}
+
+	mlog.Outf(`%s: starting HTTP server at %s://%s`, logp, scheme,
+		httpd.Options.Address)
 
 	go func() {
 		err = httpd.Server.Start()
@@ -142,27 +239,276 @@ func (httpd *httpServer) start() (err error) {
 	return nil
 }
 
+// apiDashboard publish the statistics of logs in the last 60 seconds,
+// every second, using Server-Sent event.
+//
+// Each event data is the JSON of statistics,
+//
+//	{
+//		"time": <RFC3339>,
+//		"rps_backend": {<backend>: <float>, ...},
+//		"status_class": {"2xx": <int>, ...},
+//		"term_state": {<state>: <int>, ...},
+//		"top_url": [{"key": <url>, "count": <int>}, ...],
+//		"top_client_ip": [{"key": <ip>, "count": <int>}, ...],
+//		"time_all": {"p50": <int>, "p90": <int>, "p99": <int>, "max": <int>},
+//		"window": 60,
+//		"requests": <int>
+//	}
+func (httpd *httpServer) apiDashboard(sse *libhttp.SSEConn) {
+	var (
+		logp   = `apiDashboard`
+		ticker = time.NewTicker(defDashboardInterval)
+
+		stats dashboardStats
+		now   time.Time
+		data  []byte
+		err   error
+	)
+	defer ticker.Stop()
+
+	for now = range ticker.C {
+		stats = httpd.dash.stats(now)
+
+		data, err = json.Marshal(stats)
+		if err != nil {
+			mlog.Errf(`%s: %s`, logp, err)
+			return
+		}
+
+		err = sse.WriteEvent(``, string(data), nil)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// apiLogSearch search the logs stored in database.
+//
+// Request format,
+//
+//	GET /api/log/search?from=&to=&backend=&frontend=&server=&status=
+//		&status_min=&status_max=&method=&url_prefix=&client_ip=
+//		&term_state=&limit=&cursor=
+//
+// The "from" and "to" parameters are in RFC3339 format.
+// All parameters are optional.
+//
+// On success, it will return the list of logs ordered by the newest
+// request, with the "next" cursor to fetch the next page.
+// The cookies that are not masked are removed, unless the
+// "wui_show_cookie" is true,
+//
+//	{
+//		"code": 200,
+//		"data": {
+//			"next": <string>,
+//			"list": [<HTTPLog>, ...]
+//		}
+//	}
+func (httpd *httpServer) apiLogSearch(epr *libhttp.EndpointRequest) (resb []byte, err error) {
+	var logp = `apiLogSearch`
+
+	var dbc = httpd.dbc.Load()
+	if dbc == nil {
+		return nil, &liberrors.E{
+			Code:    http.StatusServiceUnavailable,
+			Name:    `ERR_NO_DATABASE`,
+			Message: `no database to search, enable the postgresql or sqlite forwarder`,
+		}
+	}
+
+	var filter httpLogFilter
+
+	filter, err = parseHTTPLogFilter(epr.HTTPRequest.Form)
+	if err != nil {
+		return nil, err
+	}
+
+	var page httpLogPage
+
+	page, err = searchHTTPLog(dbc, dbc.DriverName, filter)
+	if err != nil {
+		mlog.Errf(`%s: %s`, logp, err)
+		return nil, liberrors.Internal(err)
+	}
+
+	if !httpd.showCookie {
+		for x := range page.List {
+			page.List[x].omitCookie()
+		}
+	}
+
+	var res = libhttp.EndpointResponse{
+		Data:  page,
+		Count: int64(len(page.List)),
+	}
+	res.Code = http.StatusOK
+
+	resb, err = json.Marshal(res)
+	if err != nil {
+		return nil, liberrors.Internal(err)
+	}
+	return resb, nil
+}
+
 // apiLogTail tail the log using Server-Sent event.
+//
+// Request format,
+//
+//	GET /api/log/tail?format=&backend=&frontend=&server=&status=
+//		&status_min=&status_max=&method=&url_prefix=&client_ip=
+//		&term_state=
+//
+// The filter parameters are the same with apiLogSearch, except "from",
+// "to", "limit", and "cursor" that are ignored.
+// If one of the filter is set, the log that cannot be parsed is not
+// published.
+//
+// If the "format" is "json", each event data is the parsed HTTPLog in
+// JSON; otherwise its the raw log as received from HAProxy.
+//
+// If the subscriber cannot keep up, the logs are dropped and the event
+// "missed" is published with the number of dropped logs as data, before
+// the next log.
+// The subscriber that miss too many logs is evicted and the connection
+// closed.
 func (httpd *httpServer) apiLogTail(sse *libhttp.SSEConn) {
 	var (
-		logp = `apiLogTail`
+		logp  = `apiLogTail`
+		query = sse.HTTPRequest.URL.Query()
 
-		tailer chan string
-		rawlog string
-		idx    int64
+		filter httpLogFilter
 		err    error
 	)
 
-	idx, tailer = httpd.registerTailer()
+	filter, err = parseHTTPLogFilter(query)
+	if err != nil {
+		_ = sse.WriteEvent(`error`, err.Error(), nil)
+		return
+	}
 
-	for rawlog = range tailer {
-		mlog.Outf(`%s: %s`, logp, rawlog)
+	var (
+		isJSON    = query.Get(`format`) == `json`
+		hasFilter = !filter.isEmpty()
+		t         = httpd.registerTailer(sse.HTTPRequest.RemoteAddr)
+		ticker    = time.NewTicker(defTailerPingInterval)
+
+		tlog *tailLog
+		data string
+		ok   bool
+	)
+	defer ticker.Stop()
 
-		err = sse.WriteEvent(``, rawlog, nil)
+	for {
+		select {
+		case tlog, ok = <-t.logq:
+			if !ok {
+				// The tailer has been evicted.
+				_ = sse.WriteEvent(`evicted`, `too many missed logs`, nil)
+				return
+			}
+
+		case <-ticker.C:
+			// Check if the subscriber still connected.
+			err = httpd.writeMissed(sse, t)
+			if err == nil {
+				err = sse.WriteRaw([]byte(":\n\n"))
+			}
+			if err != nil {
+				httpd.unregisterTailer(t.id)
+				return
+			}
+			continue
+		}
+
+		err = httpd.writeMissed(sse, t)
 		if err != nil {
 			mlog.Errf(`%s: %s`, logp, err)
-			httpd.unregisterTailer(idx)
+			httpd.unregisterTailer(t.id)
 			return
 		}
+
+		if tlog.halog == nil {
+			if isJSON || hasFilter {
+				continue
+			}
+		} else if !filter.match(tlog.halog) {
+			continue
+		}
+
+		data, err = tlog.format(isJSON)
+		if err != nil {
+			mlog.Errf(`%s: %s`, logp, err)
+			continue
+		}
+
+		err = sse.WriteEvent(``, data, nil)
+		if err != nil {
+			mlog.Errf(`%s: %s`, logp, err)
+			httpd.unregisterTailer(t.id)
+			return
+		}
+		t.sent.Add(1)
+	}
+}
+
+// writeMissed publish the event "missed" with the number of logs dropped
+// since the last notification, if any.
+func (httpd *httpServer) writeMissed(sse *libhttp.SSEConn, t *tailer) (err error) {
+	var missed = t.missed.Swap(0)
+	if missed == 0 {
+		return nil
+	}
+	return sse.WriteEvent(`missed`, strconv.FormatInt(missed, 10), nil)
+}
+
+// apiLogTailStats return the statistics of each subscriber of
+// apiLogTail.
+//
+// Response format,
+//
+//	{
+//		"code": 200,
+//		"data": {
+//			"list": [{
+//				"connected_at": <RFC3339>,
+//				"remote_addr": <string>,
+//				"id": <int>,
+//				"sent": <int>,
+//				"dropped": <int>,
+//				"queued": <int>
+//			}, ...],
+//			"evicted": <int>
+//		}
+//	}
+func (httpd *httpServer) apiLogTailStats(_ *libhttp.EndpointRequest) (resb []byte, err error) {
+	var (
+		summary tailerSummary
+		t       *tailer
+	)
+
+	httpd.tailerMtx.Lock()
+	summary.List = make([]tailerStats, 0, len(httpd.tailer))
+	for _, t = range httpd.tailer {
+		summary.List = append(summary.List, t.stats())
+	}
+	summary.Evicted = httpd.tailerEvicted
+	httpd.tailerMtx.Unlock()
+
+	sort.Slice(summary.List, func(x, y int) bool {
+		return summary.List[x].ID < summary.List[y].ID
+	})
+
+	var res = libhttp.EndpointResponse{
+		Data:  summary,
+		Count: int64(len(summary.List)),
+	}
+	res.Code = http.StatusOK
+
+	resb, err = json.Marshal(res)
+	if err != nil {
+		return nil, liberrors.Internal(err)
 	}
+	return resb, nil
 }
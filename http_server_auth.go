@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const authRealm = `Basic realm="haminer", charset="UTF-8"`
+
+// wuiGuard protect the web user interface and its HTTP APIs using the
+// source CIDR allowlist, basic authentication, and bearer tokens from
+// Config.
+type wuiGuard struct {
+	next http.Handler
+
+	users          map[string]string
+	tokens         []string
+	allowNets      []*net.IPNet
+	isAuthRequired bool
+}
+
+func newWuiGuard(cfg *Config, next http.Handler) (guard *wuiGuard) {
+	guard = &wuiGuard{
+		next:      next,
+		users:     cfg.wuiUsers,
+		tokens:    cfg.WuiToken,
+		allowNets: cfg.wuiAllowNets,
+	}
+	guard.isAuthRequired = len(guard.users) != 0 || len(guard.tokens) != 0
+	return guard
+}
+
+// ServeHTTP implement the [http.Handler] interface.
+// The request is forwarded to the next handler only if its source address
+// is allowed and, if the authentication is set, its authorized.
+func (guard *wuiGuard) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	if !guard.isAllowed(req.RemoteAddr) {
+		http.Error(res, http.StatusText(http.StatusForbidden),
+			http.StatusForbidden)
+		return
+	}
+	if guard.isAuthRequired && !guard.isAuthorized(req) {
+		if len(guard.users) != 0 {
+			res.Header().Set(`WWW-Authenticate`, authRealm)
+		}
+		http.Error(res, http.StatusText(http.StatusUnauthorized),
+			http.StatusUnauthorized)
+		return
+	}
+	guard.next.ServeHTTP(res, req)
+}
+
+// isAllowed return true if the remote address is inside one of the
+// allowed networks, or if no allowed networks is set.
+func (guard *wuiGuard) isAllowed(remoteAddr string) bool {
+	if len(guard.allowNets) == 0 {
+		return true
+	}
+
+	var host, _, err = net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	var ip = net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	var ipnet *net.IPNet
+	for _, ipnet = range guard.allowNets {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAuthorized return true if the request contains valid basic
+// authentication or bearer token.
+func (guard *wuiGuard) isAuthorized(req *http.Request) bool {
+	var user, pass, ok = req.BasicAuth()
+	if ok {
+		var exp string
+
+		exp, ok = guard.users[user]
+		if !ok {
+			return false
+		}
+		return subtle.ConstantTimeCompare([]byte(exp), []byte(pass)) == 1
+	}
+
+	var token string
+
+	token, ok = strings.CutPrefix(req.Header.Get(`Authorization`), `Bearer `)
+	if !ok || len(token) == 0 {
+		return false
+	}
+
+	var (
+		isMatch bool
+		exp     string
+	)
+	for _, exp = range guard.tokens {
+		if subtle.ConstantTimeCompare([]byte(exp), []byte(token)) == 1 {
+			isMatch = true
+		}
+	}
+	return isMatch
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestWuiGuard(t *testing.T) {
+	type testCase struct {
+		desc       string
+		remoteAddr string
+		user       string
+		pass       string
+		token      string
+		expAuthn   string
+		expStatus  int
+	}
+
+	var cfg = &Config{
+		WuiBasicAuth: []string{`admin:s3cret`},
+		WuiToken:     []string{`token1`, `token2`},
+		WuiAllowCIDR: []string{`10.0.0.0/8`, `::1/128`},
+	}
+
+	var err = cfg.initWui()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		next = http.HandlerFunc(func(res http.ResponseWriter, _ *http.Request) {
+			res.WriteHeader(http.StatusOK)
+		})
+		guard = newWuiGuard(cfg, next)
+	)
+
+	var cases = []testCase{{
+		desc:       `With source not allowed`,
+		remoteAddr: `192.168.1.1:4000`,
+		token:      `token1`,
+		expStatus:  http.StatusForbidden,
+	}, {
+		desc:       `Without authorization`,
+		remoteAddr: `10.1.2.3:4000`,
+		expStatus:  http.StatusUnauthorized,
+		expAuthn:   authRealm,
+	}, {
+		desc:       `With valid basic auth`,
+		remoteAddr: `[::1]:4000`,
+		user:       `admin`,
+		pass:       `s3cret`,
+		expStatus:  http.StatusOK,
+	}, {
+		desc:       `With invalid password`,
+		remoteAddr: `10.1.2.3:4000`,
+		user:       `admin`,
+		pass:       `secret`,
+		expStatus:  http.StatusUnauthorized,
+		expAuthn:   authRealm,
+	}, {
+		desc:       `With valid token`,
+		remoteAddr: `10.1.2.3:4000`,
+		token:      `token2`,
+		expStatus:  http.StatusOK,
+	}, {
+		desc:       `With invalid token`,
+		remoteAddr: `10.1.2.3:4000`,
+		token:      `token3`,
+		expStatus:  http.StatusUnauthorized,
+		expAuthn:   authRealm,
+	}}
+
+	var (
+		c   testCase
+		req *http.Request
+		res *httptest.ResponseRecorder
+	)
+	for _, c = range cases {
+		req = httptest.NewRequest(http.MethodGet, `/api/log/tail`, nil)
+		req.RemoteAddr = c.remoteAddr
+		if len(c.user) != 0 {
+			req.SetBasicAuth(c.user, c.pass)
+		}
+		if len(c.token) != 0 {
+			req.Header.Set(`Authorization`, `Bearer `+c.token)
+		}
+
+		res = httptest.NewRecorder()
+		guard.ServeHTTP(res, req)
+
+		test.Assert(t, c.desc, c.expStatus, res.Code)
+		test.Assert(t, c.desc+`: WWW-Authenticate`, c.expAuthn,
+			res.Header().Get(`WWW-Authenticate`))
+	}
+}
@@ -17,11 +17,21 @@ func generate__database() *memfs.Node {
 		ContentType: "",
 		GenFuncName: "generate__database",
 	}
-	node.SetMode(0o20000000755)
-	node.SetModTimeUnix(1725733352, 648363905)
+	node.SetMode(0o20000000775)
+	node.SetModTimeUnix(1792340690, 608149725)
 	node.SetName("/")
 	node.SetSize(0)
 	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0001_http_log.sql", generate__database_0001_http_log_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0002_http_log_header_jsonb.sql", generate__database_0002_http_log_header_jsonb_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0003_http_log_geoip.sql", generate__database_0003_http_log_geoip_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0004_http_log_user_agent.sql", generate__database_0004_http_log_user_agent_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0005_http_log_client.sql", generate__database_0005_http_log_client_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0006_http_log_sample_weight.sql", generate__database_0006_http_log_sample_weight_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0007_http_log_error.sql", generate__database_0007_http_log_error_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0008_http_log_header_raw.sql", generate__database_0008_http_log_header_raw_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0009_http_log_id.sql", generate__database_0009_http_log_id_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/0010_http_log_date_id_idx.sql", generate__database_0010_http_log_date_id_idx_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/sqlite", generate__database_sqlite))
 	return node
 }
 
@@ -31,12 +41,193 @@ func generate__database_0001_http_log_sql() *memfs.Node {
 		Path:        "/0001_http_log.sql",
 		ContentType: "application/sql",
 		GenFuncName: "generate__database_0001_http_log_sql",
-		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x34\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x44\x52\x4F\x50\x20\x54\x41\x42\x4C\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x43\x41\x53\x43\x41\x44\x45\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x28\x0A\x20\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x20\x20\x54\x49\x4D\x45\x53\x54\x41\x4D\x50\x20\x57\x49\x54\x48\x20\x54\x49\x4D\x45\x20\x5A\x4F\x4E\x45\x0A\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x69\x70\x20\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x66\x72\x6F\x6E\x74\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x6E\x61\x6D\x65\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x68\x74\x74\x70\x5F\x70\x72\x6F\x74\x6F\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x74\x74\x70\x5F\x6D\x65\x74\x68\x6F\x64\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x74\x74\x70\x5F\x75\x72\x6C\x20\x20\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x74\x74\x70\x5F\x71\x75\x65\x72\x79\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x63\x6F\x6F\x6B\x69\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x63\x6F\x6F\x6B\x69\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x74\x65\x72\x6D\x69\x6E\x61\x74\x69\x6F\x6E\x5F\x73\x74\x61\x74\x65\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x62\x79\x74\x65\x73\x5F\x72\x65\x61\x64\x20\x20\x20\x20\x42\x49\x47\x49\x4E\x54\x0A\x0A\x2C\x20\x73\x74\x61\x74\x75\x73\x5F\x63\x6F\x64\x65\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x70\x6F\x72\x74\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x77\x61\x69\x74\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x63\x6F\x6E\x6E\x65\x63\x74\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x61\x6C\x6C\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x61\x63\x74\x69\x76\x65\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x66\x72\x6F\x6E\x74\x65\x6E\x64\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x62\x61\x63\x6B\x65\x6E\x64\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x73\x65\x72\x76\x65\x72\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x72\x65\x74\x72\x69\x65\x73\x20\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x71\x75\x65\x75\x65\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x71\x75\x65\x75\x65\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x29\x3B\x0A\x0A\x44\x52\x4F\x50\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x69\x64\x78\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x69\x70\x0A\x2C\x20\x66\x72\x6F\x6E\x74\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x6E\x61\x6D\x65\x0A\x2C\x20\x68\x74\x74\x70\x5F\x70\x72\x6F\x74\x6F\x0A\x2C\x20\x68\x74\x74\x70\x5F\x6D\x65\x74\x68\x6F\x64\x0A\x2C\x20\x68\x74\x74\x70\x5F\x75\x72\x6C\x0A\x2C\x20\x74\x65\x72\x6D\x69\x6E\x61\x74\x69\x6F\x6E\x5F\x73\x74\x61\x74\x65\x0A\x2C\x20\x73\x74\x61\x74\x75\x73\x5F\x63\x6F\x64\x65\x0A\x29\x3B\x0A\x0A\x44\x52\x4F\x50\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x74\x69\x6D\x65\x5F\x69\x64\x78\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x74\x69\x6D\x65\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x74\x69\x6D\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x77\x61\x69\x74\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x63\x6F\x6E\x6E\x65\x63\x74\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x61\x6C\x6C\x0A\x29\x3B\x0A"),
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x34\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x4D\x69\x67\x72\x61\x74\x69\x6F\x6E\x20\x66\x69\x6C\x65\x73\x20\x6D\x75\x73\x74\x20\x62\x65\x20\x61\x64\x64\x69\x74\x69\x76\x65\x3A\x20\x64\x6F\x20\x6E\x6F\x74\x20\x64\x72\x6F\x70\x20\x6F\x72\x20\x72\x65\x63\x72\x65\x61\x74\x65\x20\x74\x68\x65\x20\x65\x78\x69\x73\x74\x69\x6E\x67\x0A\x2D\x2D\x20\x74\x61\x62\x6C\x65\x73\x2C\x20\x73\x69\x6E\x63\x65\x20\x69\x74\x20\x6D\x61\x79\x20\x63\x6F\x6E\x74\x61\x69\x6E\x73\x20\x74\x68\x65\x20\x61\x63\x63\x65\x73\x73\x20\x6C\x6F\x67\x20\x68\x69\x73\x74\x6F\x72\x79\x2E\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x54\x41\x42\x4C\x45\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x28\x0A\x20\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x20\x20\x54\x49\x4D\x45\x53\x54\x41\x4D\x50\x20\x57\x49\x54\x48\x20\x54\x49\x4D\x45\x20\x5A\x4F\x4E\x45\x0A\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x69\x70\x20\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x66\x72\x6F\x6E\x74\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x6E\x61\x6D\x65\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x68\x74\x74\x70\x5F\x70\x72\x6F\x74\x6F\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x74\x74\x70\x5F\x6D\x65\x74\x68\x6F\x64\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x74\x74\x70\x5F\x75\x72\x6C\x20\x20\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x74\x74\x70\x5F\x71\x75\x65\x72\x79\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x63\x6F\x6F\x6B\x69\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x63\x6F\x6F\x6B\x69\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x2C\x20\x74\x65\x72\x6D\x69\x6E\x61\x74\x69\x6F\x6E\x5F\x73\x74\x61\x74\x65\x20\x56\x41\x52\x43\x48\x41\x52\x0A\x0A\x2C\x20\x62\x79\x74\x65\x73\x5F\x72\x65\x61\x64\x20\x20\x20\x20\x42\x49\x47\x49\x4E\x54\x0A\x0A\x2C\x20\x73\x74\x61\x74\x75\x73\x5F\x63\x6F\x64\x65\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x70\x6F\x72\x74\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x77\x61\x69\x74\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x63\x6F\x6E\x6E\x65\x63\x74\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x61\x6C\x6C\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x61\x63\x74\x69\x76\x65\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x66\x72\x6F\x6E\x74\x65\x6E\x64\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x62\x61\x63\x6B\x65\x6E\x64\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x73\x65\x72\x76\x65\x72\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x72\x65\x74\x72\x69\x65\x73\x20\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x71\x75\x65\x75\x65\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x71\x75\x65\x75\x65\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x29\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x69\x70\x0A\x2C\x20\x66\x72\x6F\x6E\x74\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x6E\x61\x6D\x65\x0A\x2C\x20\x68\x74\x74\x70\x5F\x70\x72\x6F\x74\x6F\x0A\x2C\x20\x68\x74\x74\x70\x5F\x6D\x65\x74\x68\x6F\x64\x0A\x2C\x20\x68\x74\x74\x70\x5F\x75\x72\x6C\x0A\x2C\x20\x74\x65\x72\x6D\x69\x6E\x61\x74\x69\x6F\x6E\x5F\x73\x74\x61\x74\x65\x0A\x2C\x20\x73\x74\x61\x74\x75\x73\x5F\x63\x6F\x64\x65\x0A\x29\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x74\x69\x6D\x65\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x74\x69\x6D\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x77\x61\x69\x74\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x63\x6F\x6E\x6E\x65\x63\x74\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x61\x6C\x6C\x0A\x29\x3B\x0A"),
+	}
+	node.SetMode(0o664)
+	node.SetModTimeUnix(1792336916, 509719583)
+	node.SetName("0001_http_log.sql")
+	node.SetSize(1355)
+	return node
+}
+
+func generate__database_0002_http_log_header_jsonb_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0002_http_log_header_jsonb.sql",
+		Path:        "/0002_http_log_header_jsonb.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0002_http_log_header_jsonb_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x53\x74\x6F\x72\x65\x20\x74\x68\x65\x20\x63\x61\x70\x74\x75\x72\x65\x64\x20\x68\x65\x61\x64\x65\x72\x73\x20\x61\x73\x20\x4A\x53\x4F\x4E\x42\x2E\x0A\x2D\x2D\x20\x54\x68\x65\x20\x70\x72\x65\x76\x69\x6F\x75\x73\x20\x70\x69\x70\x65\x2D\x6A\x6F\x69\x6E\x65\x64\x20\x76\x61\x6C\x75\x65\x73\x20\x61\x72\x65\x20\x6B\x65\x70\x74\x20\x69\x6E\x20\x74\x68\x65\x20\x22\x5F\x72\x61\x77\x22\x20\x63\x6F\x6C\x75\x6D\x6E\x73\x20\x61\x6E\x64\x0A\x2D\x2D\x20\x63\x6F\x6E\x76\x65\x72\x74\x65\x64\x20\x69\x6E\x74\x6F\x20\x4A\x53\x4F\x4E\x42\x20\x62\x79\x20\x68\x61\x6D\x69\x6E\x65\x72\x20\x75\x73\x69\x6E\x67\x20\x74\x68\x65\x20\x63\x6F\x6E\x66\x69\x67\x75\x72\x65\x64\x0A\x2D\x2D\x20\x63\x61\x70\x74\x75\x72\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x68\x65\x61\x64\x65\x72\x2E\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x52\x45\x4E\x41\x4D\x45\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x54\x4F\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x72\x61\x77\x3B\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x52\x45\x4E\x41\x4D\x45\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x54\x4F\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x5F\x72\x61\x77\x3B\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x4A\x53\x4F\x4E\x42\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x4A\x53\x4F\x4E\x42\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x69\x64\x78\x0A\x20\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x55\x53\x49\x4E\x47\x20\x47\x49\x4E\x20\x28\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x29\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x5F\x69\x64\x78\x0A\x20\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x55\x53\x49\x4E\x47\x20\x47\x49\x4E\x20\x28\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x29\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792336983, 911077404)
+	node.SetName("0002_http_log_header_jsonb.sql")
+	node.SetSize(771)
+	return node
+}
+
+func generate__database_0003_http_log_geoip_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0003_http_log_geoip.sql",
+		Path:        "/0003_http_log_geoip.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0003_http_log_geoip_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x53\x74\x6F\x72\x65\x20\x74\x68\x65\x20\x6C\x6F\x63\x61\x74\x69\x6F\x6E\x20\x61\x6E\x64\x20\x61\x75\x74\x6F\x6E\x6F\x6D\x6F\x75\x73\x20\x73\x79\x73\x74\x65\x6D\x20\x6F\x66\x20\x63\x6C\x69\x65\x6E\x74\x20\x49\x50\x2C\x20\x65\x6E\x72\x69\x63\x68\x65\x64\x20\x66\x72\x6F\x6D\x0A\x2D\x2D\x20\x74\x68\x65\x20\x47\x65\x6F\x49\x50\x20\x64\x61\x74\x61\x62\x61\x73\x65\x2E\x0A\x2D\x2D\x20\x54\x68\x65\x20\x65\x78\x69\x73\x74\x69\x6E\x67\x20\x72\x6F\x77\x73\x20\x61\x72\x65\x20\x73\x65\x74\x20\x74\x6F\x20\x65\x6D\x70\x74\x79\x2C\x20\x73\x69\x6E\x63\x65\x20\x74\x68\x65\x79\x20\x61\x72\x65\x20\x6E\x6F\x74\x20\x65\x6E\x72\x69\x63\x68\x65\x64\x2E\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x67\x65\x6F\x5F\x63\x6F\x75\x6E\x74\x72\x79\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x67\x65\x6F\x5F\x63\x69\x74\x79\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x61\x73\x6E\x20\x20\x20\x20\x20\x20\x20\x20\x20\x42\x49\x47\x49\x4E\x54\x20\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x30\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x61\x73\x5F\x6F\x72\x67\x20\x20\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x67\x65\x6F\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x67\x65\x6F\x5F\x63\x6F\x75\x6E\x74\x72\x79\x0A\x2C\x20\x61\x73\x6E\x0A\x29\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792340532, 343143220)
+	node.SetName("0003_http_log_geoip.sql")
+	node.SetSize(644)
+	return node
+}
+
+func generate__database_0004_http_log_user_agent_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0004_http_log_user_agent.sql",
+		Path:        "/0004_http_log_user_agent.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0004_http_log_user_agent_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x53\x74\x6F\x72\x65\x20\x74\x68\x65\x20\x62\x72\x6F\x77\x73\x65\x72\x2C\x20\x4F\x53\x2C\x20\x64\x65\x76\x69\x63\x65\x20\x74\x79\x70\x65\x2C\x20\x61\x6E\x64\x20\x62\x6F\x74\x20\x66\x6C\x61\x67\x20\x70\x61\x72\x73\x65\x64\x20\x66\x72\x6F\x6D\x20\x74\x68\x65\x0A\x2D\x2D\x20\x63\x61\x70\x74\x75\x72\x65\x64\x20\x72\x65\x71\x75\x65\x73\x74\x20\x68\x65\x61\x64\x65\x72\x20\x22\x75\x73\x65\x72\x5F\x61\x67\x65\x6E\x74\x22\x2E\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x75\x61\x5F\x62\x72\x6F\x77\x73\x65\x72\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x75\x61\x5F\x6F\x73\x20\x20\x20\x20\x20\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x75\x61\x5F\x64\x65\x76\x69\x63\x65\x20\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x69\x73\x5F\x62\x6F\x74\x20\x20\x20\x20\x20\x42\x4F\x4F\x4C\x45\x41\x4E\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x46\x41\x4C\x53\x45\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792340532, 356064863)
+	node.SetName("0004_http_log_user_agent.sql")
+	node.SetSize(509)
+	return node
+}
+
+func generate__database_0005_http_log_client_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0005_http_log_client.sql",
+		Path:        "/0005_http_log_client.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0005_http_log_client_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x53\x74\x6F\x72\x65\x20\x74\x68\x65\x20\x6E\x65\x74\x77\x6F\x72\x6B\x20\x6C\x61\x62\x65\x6C\x20\x61\x6E\x64\x20\x72\x65\x76\x65\x72\x73\x65\x20\x44\x4E\x53\x20\x68\x6F\x73\x74\x20\x6E\x61\x6D\x65\x20\x6F\x66\x20\x63\x6C\x69\x65\x6E\x74\x20\x49\x50\x2E\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6C\x69\x65\x6E\x74\x5F\x6C\x61\x62\x65\x6C\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6C\x69\x65\x6E\x74\x5F\x68\x6F\x73\x74\x20\x20\x56\x41\x52\x43\x48\x41\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792340532, 362765717)
+	node.SetName("0005_http_log_client.sql")
+	node.SetSize(336)
+	return node
+}
+
+func generate__database_0006_http_log_sample_weight_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0006_http_log_sample_weight.sql",
+		Path:        "/0006_http_log_sample_weight.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0006_http_log_sample_weight_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x53\x74\x6F\x72\x65\x20\x74\x68\x65\x20\x6E\x75\x6D\x62\x65\x72\x20\x6F\x66\x20\x6C\x6F\x67\x73\x20\x72\x65\x70\x72\x65\x73\x65\x6E\x74\x65\x64\x20\x62\x79\x20\x65\x61\x63\x68\x20\x6C\x6F\x67\x2C\x20\x73\x65\x74\x20\x62\x79\x20\x74\x68\x65\x20\x73\x61\x6D\x70\x6C\x65\x0A\x2D\x2D\x20\x72\x75\x6C\x65\x73\x2E\x0A\x2D\x2D\x20\x54\x68\x65\x20\x65\x78\x69\x73\x74\x69\x6E\x67\x20\x72\x6F\x77\x73\x20\x61\x72\x65\x20\x6E\x6F\x74\x20\x73\x61\x6D\x70\x6C\x65\x64\x2E\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x73\x61\x6D\x70\x6C\x65\x5F\x77\x65\x69\x67\x68\x74\x20\x44\x4F\x55\x42\x4C\x45\x20\x50\x52\x45\x43\x49\x53\x49\x4F\x4E\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x31\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792340532, 371797443)
+	node.SetName("0006_http_log_sample_weight.sql")
+	node.SetSize(329)
+	return node
+}
+
+func generate__database_0007_http_log_error_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0007_http_log_error.sql",
+		Path:        "/0007_http_log_error.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0007_http_log_error_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x53\x74\x6F\x72\x65\x20\x74\x68\x65\x20\x66\x6C\x61\x67\x20\x6F\x66\x20\x72\x65\x71\x75\x65\x73\x74\x20\x74\x68\x61\x74\x20\x69\x73\x20\x6E\x6F\x74\x20\x66\x6F\x72\x77\x61\x72\x64\x65\x64\x20\x74\x6F\x20\x61\x6E\x79\x20\x73\x65\x72\x76\x65\x72\x2C\x20\x77\x69\x74\x68\x0A\x2D\x2D\x20\x73\x65\x72\x76\x65\x72\x20\x22\x3C\x4E\x4F\x53\x52\x56\x3E\x22\x20\x6F\x72\x20\x62\x61\x63\x6B\x65\x6E\x64\x20\x22\x2D\x22\x2E\x0A\x2D\x2D\x20\x54\x68\x65\x20\x65\x78\x69\x73\x74\x69\x6E\x67\x20\x72\x6F\x77\x73\x20\x61\x72\x65\x20\x6E\x6F\x74\x20\x65\x72\x72\x6F\x72\x2C\x20\x73\x69\x6E\x63\x65\x20\x74\x68\x6F\x73\x65\x20\x6C\x6F\x67\x73\x20\x77\x65\x72\x65\x20\x72\x65\x6A\x65\x63\x74\x65\x64\x2E\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x69\x73\x5F\x65\x72\x72\x6F\x72\x20\x42\x4F\x4F\x4C\x45\x41\x4E\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x46\x41\x4C\x53\x45\x3B\x0A"),
 	}
 	node.SetMode(0o644)
-	node.SetModTimeUnix(1766994142, 55045808)
+	node.SetModTimeUnix(1792340532, 384149725)
+	node.SetName("0007_http_log_error.sql")
+	node.SetSize(375)
+	return node
+}
+
+func generate__database_0008_http_log_header_raw_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0008_http_log_header_raw.sql",
+		Path:        "/0008_http_log_header_raw.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0008_http_log_header_raw_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x43\x6F\x6E\x76\x65\x72\x74\x20\x74\x68\x65\x20\x70\x69\x70\x65\x2D\x6A\x6F\x69\x6E\x65\x64\x20\x72\x65\x71\x75\x65\x73\x74\x20\x68\x65\x61\x64\x65\x72\x73\x2C\x20\x73\x74\x6F\x72\x65\x64\x20\x62\x65\x66\x6F\x72\x65\x20\x6D\x69\x67\x72\x61\x74\x69\x6F\x6E\x0A\x2D\x2D\x20\x22\x30\x30\x30\x32\x5F\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x68\x65\x61\x64\x65\x72\x5F\x6A\x73\x6F\x6E\x62\x2E\x73\x71\x6C\x22\x2C\x20\x69\x6E\x74\x6F\x20\x4A\x53\x4F\x4E\x42\x20\x75\x73\x69\x6E\x67\x20\x74\x68\x65\x20\x6E\x61\x6D\x65\x73\x20\x66\x72\x6F\x6D\x20\x74\x68\x65\x0A\x2D\x2D\x20\x73\x65\x73\x73\x69\x6F\x6E\x20\x73\x65\x74\x74\x69\x6E\x67\x20\x22\x68\x61\x6D\x69\x6E\x65\x72\x2E\x63\x61\x70\x74\x75\x72\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x68\x65\x61\x64\x65\x72\x22\x2C\x20\x73\x65\x74\x20\x62\x79\x20\x68\x61\x6D\x69\x6E\x65\x72\x20\x66\x72\x6F\x6D\x0A\x2D\x2D\x20\x74\x68\x65\x20\x6F\x70\x74\x69\x6F\x6E\x20\x63\x61\x70\x74\x75\x72\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x68\x65\x61\x64\x65\x72\x2E\x0A\x2D\x2D\x20\x4F\x6E\x6C\x79\x20\x74\x68\x65\x20\x72\x6F\x77\x73\x20\x77\x68\x65\x72\x65\x20\x74\x68\x65\x20\x6E\x75\x6D\x62\x65\x72\x20\x6F\x66\x20\x76\x61\x6C\x75\x65\x73\x20\x65\x71\x75\x61\x6C\x20\x74\x6F\x20\x74\x68\x65\x20\x6E\x75\x6D\x62\x65\x72\x20\x6F\x66\x20\x6E\x61\x6D\x65\x73\x0A\x2D\x2D\x20\x61\x72\x65\x20\x63\x6F\x6E\x76\x65\x72\x74\x65\x64\x2C\x20\x74\x68\x65\x20\x72\x65\x73\x74\x20\x69\x73\x20\x6B\x65\x70\x74\x20\x69\x6E\x20\x63\x6F\x6C\x75\x6D\x6E\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x72\x61\x77\x2E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x54\x68\x65\x20\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x68\x65\x61\x64\x65\x72\x73\x20\x69\x73\x20\x6E\x65\x76\x65\x72\x20\x63\x61\x70\x74\x75\x72\x65\x64\x20\x62\x79\x20\x68\x61\x6D\x69\x6E\x65\x72\x2C\x20\x73\x6F\x20\x74\x68\x65\x20\x63\x6F\x6C\x75\x6D\x6E\x0A\x2D\x2D\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x5F\x72\x61\x77\x20\x69\x73\x20\x64\x72\x6F\x70\x70\x65\x64\x20\x69\x66\x20\x69\x74\x73\x20\x64\x6F\x65\x73\x20\x6E\x6F\x74\x20\x63\x6F\x6E\x74\x61\x69\x6E\x73\x20\x61\x6E\x79\x20\x76\x61\x6C\x75\x65\x2E\x0A\x0A\x55\x50\x44\x41\x54\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x53\x45\x54\x0A\x20\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x3D\x20\x28\x0A\x20\x20\x20\x20\x53\x45\x4C\x45\x43\x54\x20\x6A\x73\x6F\x6E\x62\x5F\x6F\x62\x6A\x65\x63\x74\x5F\x61\x67\x67\x28\x68\x2E\x6B\x2C\x20\x68\x2E\x76\x29\x0A\x20\x20\x20\x20\x46\x52\x4F\x4D\x20\x75\x6E\x6E\x65\x73\x74\x28\x0A\x20\x20\x20\x20\x20\x20\x73\x74\x72\x69\x6E\x67\x5F\x74\x6F\x5F\x61\x72\x72\x61\x79\x28\x63\x75\x72\x72\x65\x6E\x74\x5F\x73\x65\x74\x74\x69\x6E\x67\x28\x27\x68\x61\x6D\x69\x6E\x65\x72\x2E\x63\x61\x70\x74\x75\x72\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x68\x65\x61\x64\x65\x72\x27\x2C\x20\x74\x72\x75\x65\x29\x2C\x20\x27\x2C\x27\x29\x0A\x20\x20\x20\x20\x2C\x20\x73\x74\x72\x69\x6E\x67\x5F\x74\x6F\x5F\x61\x72\x72\x61\x79\x28\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x72\x61\x77\x2C\x20\x27\x7C\x27\x29\x0A\x20\x20\x20\x20\x29\x20\x41\x53\x20\x68\x28\x6B\x2C\x20\x76\x29\x0A\x20\x20\x29\x0A\x2C\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x72\x61\x77\x20\x3D\x20\x4E\x55\x4C\x4C\x0A\x57\x48\x45\x52\x45\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x49\x53\x20\x4E\x55\x4C\x4C\x0A\x20\x20\x41\x4E\x44\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x72\x61\x77\x20\x3C\x3E\x20\x27\x27\x0A\x20\x20\x41\x4E\x44\x20\x63\x6F\x61\x6C\x65\x73\x63\x65\x28\x63\x75\x72\x72\x65\x6E\x74\x5F\x73\x65\x74\x74\x69\x6E\x67\x28\x27\x68\x61\x6D\x69\x6E\x65\x72\x2E\x63\x61\x70\x74\x75\x72\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x68\x65\x61\x64\x65\x72\x27\x2C\x20\x74\x72\x75\x65\x29\x2C\x20\x27\x27\x29\x20\x3C\x3E\x20\x27\x27\x0A\x20\x20\x41\x4E\x44\x20\x63\x61\x72\x64\x69\x6E\x61\x6C\x69\x74\x79\x28\x73\x74\x72\x69\x6E\x67\x5F\x74\x6F\x5F\x61\x72\x72\x61\x79\x28\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x72\x61\x77\x2C\x20\x27\x7C\x27\x29\x29\x0A\x20\x20\x20\x20\x3D\x20\x63\x61\x72\x64\x69\x6E\x61\x6C\x69\x74\x79\x28\x73\x74\x72\x69\x6E\x67\x5F\x74\x6F\x5F\x61\x72\x72\x61\x79\x28\x63\x75\x72\x72\x65\x6E\x74\x5F\x73\x65\x74\x74\x69\x6E\x67\x28\x27\x68\x61\x6D\x69\x6E\x65\x72\x2E\x63\x61\x70\x74\x75\x72\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x5F\x68\x65\x61\x64\x65\x72\x27\x2C\x20\x74\x72\x75\x65\x29\x2C\x20\x27\x2C\x27\x29\x29\x3B\x0A\x0A\x44\x4F\x20\x24\x24\x0A\x42\x45\x47\x49\x4E\x0A\x20\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x28\x53\x45\x4C\x45\x43\x54\x20\x31\x20\x46\x52\x4F\x4D\x20\x69\x6E\x66\x6F\x72\x6D\x61\x74\x69\x6F\x6E\x5F\x73\x63\x68\x65\x6D\x61\x2E\x63\x6F\x6C\x75\x6D\x6E\x73\x0A\x20\x20\x20\x20\x57\x48\x45\x52\x45\x20\x74\x61\x62\x6C\x65\x5F\x6E\x61\x6D\x65\x20\x3D\x20\x27\x68\x74\x74\x70\x5F\x6C\x6F\x67\x27\x20\x41\x4E\x44\x20\x63\x6F\x6C\x75\x6D\x6E\x5F\x6E\x61\x6D\x65\x20\x3D\x20\x27\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x5F\x72\x61\x77\x27\x29\x0A\x20\x20\x54\x48\x45\x4E\x0A\x20\x20\x20\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x28\x53\x45\x4C\x45\x43\x54\x20\x31\x20\x46\x52\x4F\x4D\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x57\x48\x45\x52\x45\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x5F\x72\x61\x77\x20\x3C\x3E\x20\x27\x27\x29\x20\x54\x48\x45\x4E\x0A\x20\x20\x20\x20\x20\x20\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x44\x52\x4F\x50\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x5F\x72\x61\x77\x3B\x0A\x20\x20\x20\x20\x45\x4E\x44\x20\x49\x46\x3B\x0A\x20\x20\x45\x4E\x44\x20\x49\x46\x3B\x0A\x45\x4E\x44\x20\x24\x24\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792340654, 41444007)
+	node.SetName("0008_http_log_header_raw.sql")
+	node.SetSize(1537)
+	return node
+}
+
+func generate__database_0009_http_log_id_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0009_http_log_id.sql",
+		Path:        "/0009_http_log_id.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0009_http_log_id_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x41\x64\x64\x20\x75\x6E\x69\x71\x75\x65\x20\x69\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x20\x66\x6F\x72\x20\x65\x61\x63\x68\x20\x6C\x6F\x67\x2C\x20\x75\x73\x65\x64\x20\x61\x73\x20\x74\x69\x65\x62\x72\x65\x61\x6B\x65\x72\x20\x6F\x6E\x20\x74\x68\x65\x20\x73\x65\x61\x72\x63\x68\x0A\x2D\x2D\x20\x63\x75\x72\x73\x6F\x72\x20\x73\x69\x6E\x63\x65\x20\x74\x68\x65\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x20\x69\x73\x20\x6E\x6F\x74\x20\x75\x6E\x69\x71\x75\x65\x2E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x54\x68\x65\x20\x63\x6F\x6C\x75\x6D\x6E\x20\x69\x73\x20\x61\x64\x64\x65\x64\x20\x77\x69\x74\x68\x6F\x75\x74\x20\x64\x65\x66\x61\x75\x6C\x74\x2C\x20\x73\x6F\x20\x74\x68\x65\x20\x65\x78\x69\x73\x74\x69\x6E\x67\x20\x72\x6F\x77\x73\x20\x61\x72\x65\x20\x6E\x6F\x74\x0A\x2D\x2D\x20\x72\x65\x77\x72\x69\x74\x74\x65\x6E\x20\x61\x6E\x64\x20\x74\x68\x65\x20\x74\x61\x62\x6C\x65\x20\x69\x73\x20\x6C\x6F\x63\x6B\x65\x64\x20\x6F\x6E\x6C\x79\x20\x62\x72\x69\x65\x66\x6C\x79\x2E\x0A\x2D\x2D\x20\x54\x68\x65\x20\x73\x65\x71\x75\x65\x6E\x63\x65\x20\x64\x65\x66\x61\x75\x6C\x74\x20\x69\x73\x20\x73\x65\x74\x20\x61\x66\x74\x65\x72\x77\x61\x72\x64\x2C\x20\x73\x6F\x20\x6F\x6E\x6C\x79\x20\x74\x68\x65\x20\x6E\x65\x77\x20\x72\x6F\x77\x73\x20\x67\x65\x74\x20\x74\x68\x65\x20\x69\x64\x2E\x0A\x2D\x2D\x20\x54\x68\x65\x20\x65\x78\x69\x73\x74\x69\x6E\x67\x20\x72\x6F\x77\x73\x20\x61\x72\x65\x20\x62\x61\x63\x6B\x66\x69\x6C\x6C\x65\x64\x20\x75\x73\x69\x6E\x67\x20\x22\x68\x61\x6D\x69\x6E\x65\x72\x20\x6D\x69\x67\x72\x61\x74\x65\x20\x2D\x62\x61\x63\x6B\x66\x69\x6C\x6C\x2D\x69\x64\x22\x2E\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x44\x44\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x69\x64\x20\x42\x49\x47\x49\x4E\x54\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x53\x45\x51\x55\x45\x4E\x43\x45\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x69\x64\x5F\x73\x65\x71\x0A\x20\x20\x4F\x57\x4E\x45\x44\x20\x42\x59\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x2E\x69\x64\x3B\x0A\x0A\x41\x4C\x54\x45\x52\x20\x54\x41\x42\x4C\x45\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x0A\x20\x20\x41\x4C\x54\x45\x52\x20\x43\x4F\x4C\x55\x4D\x4E\x20\x69\x64\x20\x53\x45\x54\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x6E\x65\x78\x74\x76\x61\x6C\x28\x27\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x69\x64\x5F\x73\x65\x71\x27\x29\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792344240, 804363320)
+	node.SetName("0009_http_log_id.sql")
+	node.SetSize(713)
+	return node
+}
+
+func generate__database_0010_http_log_date_id_idx_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/0010_http_log_date_id_idx.sql",
+		Path:        "/0010_http_log_date_id_idx.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_0010_http_log_date_id_idx_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x49\x6E\x64\x65\x78\x20\x66\x6F\x72\x20\x73\x65\x61\x72\x63\x68\x69\x6E\x67\x20\x74\x68\x65\x20\x6C\x6F\x67\x73\x20\x6F\x72\x64\x65\x72\x65\x64\x20\x62\x79\x20\x74\x68\x65\x20\x6E\x65\x77\x65\x73\x74\x20\x72\x65\x71\x75\x65\x73\x74\x2C\x20\x77\x69\x74\x68\x20\x74\x68\x65\x0A\x2D\x2D\x20\x63\x6F\x6C\x75\x6D\x6E\x20\x69\x64\x20\x61\x73\x20\x74\x69\x65\x62\x72\x65\x61\x6B\x65\x72\x20\x66\x6F\x72\x20\x74\x68\x65\x20\x73\x61\x6D\x65\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x2E\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x64\x61\x74\x65\x5F\x69\x64\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x20\x44\x45\x53\x43\x0A\x2C\x20\x69\x64\x20\x44\x45\x53\x43\x0A\x29\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792345509, 116438712)
+	node.SetName("0010_http_log_date_id_idx.sql")
+	node.SetSize(331)
+	return node
+}
+
+func generate__database_sqlite() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/sqlite",
+		Path:        "/sqlite",
+		ContentType: "",
+		GenFuncName: "generate__database_sqlite",
+	}
+	node.SetMode(0o20000000755)
+	node.SetModTimeUnix(1792345341, 439079277)
+	node.SetName("sqlite")
+	node.SetSize(0)
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/sqlite/0001_http_log.sql", generate__database_sqlite_0001_http_log_sql))
+	node.AddChild(_memfsDatabase_getNode(memfsDatabase, "/sqlite/0002_http_log_date_id_idx.sql", generate__database_sqlite_0002_http_log_date_id_idx_sql))
+	return node
+}
+
+func generate__database_sqlite_0001_http_log_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/sqlite/0001_http_log.sql",
+		Path:        "/sqlite/0001_http_log.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_sqlite_0001_http_log_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x4D\x69\x67\x72\x61\x74\x69\x6F\x6E\x20\x66\x69\x6C\x65\x73\x20\x66\x6F\x72\x20\x53\x51\x4C\x69\x74\x65\x20\x6D\x75\x73\x74\x20\x62\x65\x20\x61\x64\x64\x69\x74\x69\x76\x65\x3A\x20\x64\x6F\x20\x6E\x6F\x74\x20\x64\x72\x6F\x70\x20\x6F\x72\x20\x72\x65\x63\x72\x65\x61\x74\x65\x0A\x2D\x2D\x20\x74\x68\x65\x20\x65\x78\x69\x73\x74\x69\x6E\x67\x20\x74\x61\x62\x6C\x65\x73\x2C\x20\x73\x69\x6E\x63\x65\x20\x69\x74\x20\x6D\x61\x79\x20\x63\x6F\x6E\x74\x61\x69\x6E\x73\x20\x74\x68\x65\x20\x61\x63\x63\x65\x73\x73\x20\x6C\x6F\x67\x20\x68\x69\x73\x74\x6F\x72\x79\x2E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x54\x68\x65\x20\x63\x6F\x6C\x75\x6D\x6E\x20\x69\x64\x20\x69\x73\x20\x61\x6C\x69\x61\x73\x20\x6F\x66\x20\x72\x6F\x77\x69\x64\x2C\x20\x75\x73\x65\x64\x20\x61\x73\x20\x74\x69\x65\x62\x72\x65\x61\x6B\x65\x72\x20\x6F\x6E\x20\x73\x65\x61\x72\x63\x68\x2E\x0A\x2D\x2D\x20\x54\x68\x65\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x20\x69\x73\x20\x64\x65\x63\x6C\x61\x72\x65\x64\x20\x61\x73\x20\x44\x41\x54\x45\x54\x49\x4D\x45\x20\x73\x6F\x20\x74\x68\x65\x20\x64\x72\x69\x76\x65\x72\x20\x70\x61\x72\x73\x65\x20\x69\x74\x20\x62\x61\x63\x6B\x0A\x2D\x2D\x20\x69\x6E\x74\x6F\x20\x74\x69\x6D\x65\x2E\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x54\x41\x42\x4C\x45\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x20\x28\x0A\x20\x20\x69\x64\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x20\x50\x52\x49\x4D\x41\x52\x59\x20\x4B\x45\x59\x0A\x2C\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x20\x20\x44\x41\x54\x45\x54\x49\x4D\x45\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x69\x70\x20\x20\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x6C\x61\x62\x65\x6C\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x68\x6F\x73\x74\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x67\x65\x6F\x5F\x63\x6F\x75\x6E\x74\x72\x79\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x67\x65\x6F\x5F\x63\x69\x74\x79\x20\x20\x20\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x61\x73\x6E\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x30\x0A\x2C\x20\x61\x73\x5F\x6F\x72\x67\x20\x20\x20\x20\x20\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x75\x61\x5F\x62\x72\x6F\x77\x73\x65\x72\x20\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x75\x61\x5F\x6F\x73\x20\x20\x20\x20\x20\x20\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x75\x61\x5F\x64\x65\x76\x69\x63\x65\x20\x20\x20\x20\x20\x54\x45\x58\x54\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x27\x27\x0A\x2C\x20\x69\x73\x5F\x62\x6F\x74\x20\x20\x20\x20\x20\x20\x20\x20\x42\x4F\x4F\x4C\x45\x41\x4E\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x46\x41\x4C\x53\x45\x0A\x2C\x20\x69\x73\x5F\x65\x72\x72\x6F\x72\x20\x20\x20\x20\x20\x20\x42\x4F\x4F\x4C\x45\x41\x4E\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x46\x41\x4C\x53\x45\x0A\x2C\x20\x66\x72\x6F\x6E\x74\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x20\x54\x45\x58\x54\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x6E\x61\x6D\x65\x20\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x68\x74\x74\x70\x5F\x70\x72\x6F\x74\x6F\x20\x20\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x68\x74\x74\x70\x5F\x6D\x65\x74\x68\x6F\x64\x20\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x68\x74\x74\x70\x5F\x75\x72\x6C\x20\x20\x20\x20\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x68\x74\x74\x70\x5F\x71\x75\x65\x72\x79\x20\x20\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x68\x65\x61\x64\x65\x72\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x54\x45\x58\x54\x0A\x2C\x20\x63\x6F\x6F\x6B\x69\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x63\x6F\x6F\x6B\x69\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x20\x20\x54\x45\x58\x54\x0A\x2C\x20\x74\x65\x72\x6D\x69\x6E\x61\x74\x69\x6F\x6E\x5F\x73\x74\x61\x74\x65\x20\x54\x45\x58\x54\x0A\x2C\x20\x62\x79\x74\x65\x73\x5F\x72\x65\x61\x64\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x73\x61\x6D\x70\x6C\x65\x5F\x77\x65\x69\x67\x68\x74\x20\x52\x45\x41\x4C\x20\x4E\x4F\x54\x20\x4E\x55\x4C\x4C\x20\x44\x45\x46\x41\x55\x4C\x54\x20\x31\x0A\x2C\x20\x73\x74\x61\x74\x75\x73\x5F\x63\x6F\x64\x65\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6C\x69\x65\x6E\x74\x5F\x70\x6F\x72\x74\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x71\x75\x65\x73\x74\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x77\x61\x69\x74\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x63\x6F\x6E\x6E\x65\x63\x74\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x72\x65\x73\x70\x6F\x6E\x73\x65\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x74\x69\x6D\x65\x5F\x61\x6C\x6C\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x61\x63\x74\x69\x76\x65\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x66\x72\x6F\x6E\x74\x65\x6E\x64\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x62\x61\x63\x6B\x65\x6E\x64\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x63\x6F\x6E\x6E\x5F\x73\x65\x72\x76\x65\x72\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x72\x65\x74\x72\x69\x65\x73\x20\x20\x20\x20\x20\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x73\x65\x72\x76\x65\x72\x5F\x71\x75\x65\x75\x65\x20\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x2C\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x71\x75\x65\x75\x65\x20\x49\x4E\x54\x45\x47\x45\x52\x0A\x29\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x64\x61\x74\x65\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x29\x3B\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x62\x61\x63\x6B\x65\x6E\x64\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x62\x61\x63\x6B\x65\x6E\x64\x5F\x6E\x61\x6D\x65\x0A\x2C\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x0A\x29\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792345341, 439079277)
 	node.SetName("0001_http_log.sql")
-	node.SetSize(1326)
+	node.SetSize(1861)
+	return node
+}
+
+func generate__database_sqlite_0002_http_log_date_id_idx_sql() *memfs.Node {
+	var node = &memfs.Node{
+		SysPath:     "_database/sqlite/0002_http_log_date_id_idx.sql",
+		Path:        "/sqlite/0002_http_log_date_id_idx.sql",
+		ContentType: "application/sql",
+		GenFuncName: "generate__database_sqlite_0002_http_log_date_id_idx_sql",
+		Content:     []byte("\x2D\x2D\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x36\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2D\x2D\x0A\x2D\x2D\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x0A\x2D\x2D\x20\x49\x6E\x64\x65\x78\x20\x66\x6F\x72\x20\x73\x65\x61\x72\x63\x68\x69\x6E\x67\x20\x74\x68\x65\x20\x6C\x6F\x67\x73\x20\x6F\x72\x64\x65\x72\x65\x64\x20\x62\x79\x20\x74\x68\x65\x20\x6E\x65\x77\x65\x73\x74\x20\x72\x65\x71\x75\x65\x73\x74\x2C\x20\x77\x69\x74\x68\x20\x74\x68\x65\x0A\x2D\x2D\x20\x63\x6F\x6C\x75\x6D\x6E\x20\x69\x64\x20\x61\x73\x20\x74\x69\x65\x62\x72\x65\x61\x6B\x65\x72\x20\x66\x6F\x72\x20\x74\x68\x65\x20\x73\x61\x6D\x65\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x2E\x0A\x2D\x2D\x20\x49\x74\x20\x72\x65\x70\x6C\x61\x63\x65\x20\x74\x68\x65\x20\x69\x6E\x64\x65\x78\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x64\x61\x74\x65\x5F\x69\x64\x78\x2E\x0A\x0A\x43\x52\x45\x41\x54\x45\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x4E\x4F\x54\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x64\x61\x74\x65\x5F\x69\x64\x5F\x69\x64\x78\x20\x4F\x4E\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x28\x0A\x20\x20\x72\x65\x71\x75\x65\x73\x74\x5F\x64\x61\x74\x65\x20\x44\x45\x53\x43\x0A\x2C\x20\x69\x64\x20\x44\x45\x53\x43\x0A\x29\x3B\x0A\x0A\x44\x52\x4F\x50\x20\x49\x4E\x44\x45\x58\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x68\x74\x74\x70\x5F\x6C\x6F\x67\x5F\x64\x61\x74\x65\x5F\x69\x64\x78\x3B\x0A"),
+	}
+	node.SetMode(0o644)
+	node.SetModTimeUnix(1792345509, 121492456)
+	node.SetName("0002_http_log_date_id_idx.sql")
+	node.SetSize(415)
 	return node
 }
 
@@ -78,6 +269,30 @@ func init() {
 		_memfsDatabase_getNode(memfsDatabase, "/", generate__database))
 	memfsDatabase.PathNodes.Set("/0001_http_log.sql",
 		_memfsDatabase_getNode(memfsDatabase, "/0001_http_log.sql", generate__database_0001_http_log_sql))
+	memfsDatabase.PathNodes.Set("/0002_http_log_header_jsonb.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0002_http_log_header_jsonb.sql", generate__database_0002_http_log_header_jsonb_sql))
+	memfsDatabase.PathNodes.Set("/0003_http_log_geoip.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0003_http_log_geoip.sql", generate__database_0003_http_log_geoip_sql))
+	memfsDatabase.PathNodes.Set("/0004_http_log_user_agent.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0004_http_log_user_agent.sql", generate__database_0004_http_log_user_agent_sql))
+	memfsDatabase.PathNodes.Set("/0005_http_log_client.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0005_http_log_client.sql", generate__database_0005_http_log_client_sql))
+	memfsDatabase.PathNodes.Set("/0006_http_log_sample_weight.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0006_http_log_sample_weight.sql", generate__database_0006_http_log_sample_weight_sql))
+	memfsDatabase.PathNodes.Set("/0007_http_log_error.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0007_http_log_error.sql", generate__database_0007_http_log_error_sql))
+	memfsDatabase.PathNodes.Set("/0008_http_log_header_raw.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0008_http_log_header_raw.sql", generate__database_0008_http_log_header_raw_sql))
+	memfsDatabase.PathNodes.Set("/0009_http_log_id.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0009_http_log_id.sql", generate__database_0009_http_log_id_sql))
+	memfsDatabase.PathNodes.Set("/0010_http_log_date_id_idx.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/0010_http_log_date_id_idx.sql", generate__database_0010_http_log_date_id_idx_sql))
+	memfsDatabase.PathNodes.Set("/sqlite",
+		_memfsDatabase_getNode(memfsDatabase, "/sqlite", generate__database_sqlite))
+	memfsDatabase.PathNodes.Set("/sqlite/0001_http_log.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/sqlite/0001_http_log.sql", generate__database_sqlite_0001_http_log_sql))
+	memfsDatabase.PathNodes.Set("/sqlite/0002_http_log_date_id_idx.sql",
+		_memfsDatabase_getNode(memfsDatabase, "/sqlite/0002_http_log_date_id_idx.sql", generate__database_sqlite_0002_http_log_date_id_idx_sql))
 
 	memfsDatabase.Root = memfsDatabase.PathNodes.Get("/")
 
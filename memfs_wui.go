@@ -17,8 +17,8 @@ func generate__wui() *memfs.Node {
 		ContentType: "",
 		GenFuncName: "generate__wui",
 	}
-	node.SetMode(0o20000000755)
-	node.SetModTimeUnix(1766992573, 296296996)
+	node.SetMode(0o20000000775)
+	node.SetModTimeUnix(1792337789, 730900402)
 	node.SetName("/")
 	node.SetSize(0)
 	node.AddChild(_memfsWUI_getNode(memfsWUI, "/index.html", generate__wui_index_html))
@@ -32,12 +32,12 @@ func generate__wui_index_html() *memfs.Node {
 		Path:        "/index.html",
 		ContentType: "text/html; charset=utf-8",
 		GenFuncName: "generate__wui_index_html",
-		Content:     []byte("\x3C\x68\x74\x6D\x6C\x20\x6C\x61\x6E\x67\x3D\x22\x65\x6E\x22\x3E\x0A\x0A\x3C\x68\x65\x61\x64\x3E\x0A\x20\x20\x20\x20\x3C\x73\x63\x72\x69\x70\x74\x20\x73\x72\x63\x3D\x22\x2F\x69\x6E\x64\x65\x78\x2E\x6A\x73\x22\x3E\x3C\x2F\x73\x63\x72\x69\x70\x74\x3E\x0A\x3C\x2F\x68\x65\x61\x64\x3E\x0A\x0A\x3C\x62\x6F\x64\x79\x3E\x0A\x20\x20\x20\x20\x54\x65\x73\x74\x2E\x0A\x20\x20\x20\x20\x3C\x64\x69\x76\x20\x69\x64\x3D\x22\x6C\x6F\x67\x2D\x74\x61\x69\x6C\x22\x3E\x0A\x20\x20\x20\x20\x3C\x2F\x64\x69\x76\x3E\x0A\x0A\x20\x20\x20\x20\x3C\x73\x63\x72\x69\x70\x74\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x68\x61\x6D\x69\x6E\x65\x72\x2E\x61\x70\x69\x4C\x6F\x67\x54\x61\x69\x6C\x28\x22\x6C\x6F\x67\x2D\x74\x61\x69\x6C\x22\x29\x3B\x0A\x20\x20\x20\x20\x3C\x2F\x73\x63\x72\x69\x70\x74\x3E\x0A\x3C\x2F\x62\x6F\x64\x79\x3E\x0A\x0A\x3C\x2F\x68\x74\x6D\x6C\x3E\x0A"),
+		Content:     []byte("\x3C\x21\x2D\x2D\x0A\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x34\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x2D\x2D\x3E\x0A\x3C\x68\x74\x6D\x6C\x20\x6C\x61\x6E\x67\x3D\x22\x65\x6E\x22\x3E\x0A\x0A\x3C\x68\x65\x61\x64\x3E\x0A\x20\x20\x20\x20\x3C\x6D\x65\x74\x61\x20\x63\x68\x61\x72\x73\x65\x74\x3D\x22\x75\x74\x66\x2D\x38\x22\x3E\x0A\x20\x20\x20\x20\x3C\x74\x69\x74\x6C\x65\x3E\x68\x61\x6D\x69\x6E\x65\x72\x3C\x2F\x74\x69\x74\x6C\x65\x3E\x0A\x20\x20\x20\x20\x3C\x73\x63\x72\x69\x70\x74\x20\x73\x72\x63\x3D\x22\x2F\x69\x6E\x64\x65\x78\x2E\x6A\x73\x22\x3E\x3C\x2F\x73\x63\x72\x69\x70\x74\x3E\x0A\x20\x20\x20\x20\x3C\x73\x74\x79\x6C\x65\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x62\x6F\x64\x79\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x66\x6F\x6E\x74\x2D\x66\x61\x6D\x69\x6C\x79\x3A\x20\x73\x61\x6E\x73\x2D\x73\x65\x72\x69\x66\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x66\x6F\x6E\x74\x2D\x73\x69\x7A\x65\x3A\x20\x31\x34\x70\x78\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x2E\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x64\x69\x73\x70\x6C\x61\x79\x3A\x20\x66\x6C\x65\x78\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x66\x6C\x65\x78\x2D\x77\x72\x61\x70\x3A\x20\x77\x72\x61\x70\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x67\x61\x70\x3A\x20\x31\x65\x6D\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x2E\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x20\x73\x65\x63\x74\x69\x6F\x6E\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x62\x6F\x72\x64\x65\x72\x3A\x20\x31\x70\x78\x20\x73\x6F\x6C\x69\x64\x20\x23\x63\x63\x63\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x6D\x69\x6E\x2D\x77\x69\x64\x74\x68\x3A\x20\x31\x36\x65\x6D\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x70\x61\x64\x64\x69\x6E\x67\x3A\x20\x30\x2E\x35\x65\x6D\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x2E\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x20\x68\x33\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x6D\x61\x72\x67\x69\x6E\x3A\x20\x30\x20\x30\x20\x30\x2E\x35\x65\x6D\x20\x30\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x2E\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x20\x74\x64\x2E\x6E\x75\x6D\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x74\x65\x78\x74\x2D\x61\x6C\x69\x67\x6E\x3A\x20\x72\x69\x67\x68\x74\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x23\x6C\x6F\x67\x2D\x74\x61\x69\x6C\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x66\x6F\x6E\x74\x2D\x66\x61\x6D\x69\x6C\x79\x3A\x20\x6D\x6F\x6E\x6F\x73\x70\x61\x63\x65\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x77\x68\x69\x74\x65\x2D\x73\x70\x61\x63\x65\x3A\x20\x70\x72\x65\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x3C\x2F\x73\x74\x79\x6C\x65\x3E\x0A\x3C\x2F\x68\x65\x61\x64\x3E\x0A\x0A\x3C\x62\x6F\x64\x79\x3E\x0A\x20\x20\x20\x20\x3C\x68\x32\x3E\x44\x61\x73\x68\x62\x6F\x61\x72\x64\x3C\x2F\x68\x32\x3E\x0A\x20\x20\x20\x20\x3C\x64\x69\x76\x20\x69\x64\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x2D\x73\x75\x6D\x6D\x61\x72\x79\x22\x3E\x3C\x2F\x64\x69\x76\x3E\x0A\x20\x20\x20\x20\x3C\x64\x69\x76\x20\x63\x6C\x61\x73\x73\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x22\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x68\x33\x3E\x52\x65\x71\x75\x65\x73\x74\x73\x20\x70\x65\x72\x20\x73\x65\x63\x6F\x6E\x64\x20\x62\x79\x20\x62\x61\x63\x6B\x65\x6E\x64\x3C\x2F\x68\x33\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x74\x61\x62\x6C\x65\x20\x69\x64\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x2D\x72\x70\x73\x2D\x62\x61\x63\x6B\x65\x6E\x64\x22\x3E\x3C\x2F\x74\x61\x62\x6C\x65\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x2F\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x68\x33\x3E\x53\x74\x61\x74\x75\x73\x20\x63\x6C\x61\x73\x73\x3C\x2F\x68\x33\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x74\x61\x62\x6C\x65\x20\x69\x64\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x2D\x73\x74\x61\x74\x75\x73\x2D\x63\x6C\x61\x73\x73\x22\x3E\x3C\x2F\x74\x61\x62\x6C\x65\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x2F\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x68\x33\x3E\x54\x69\x6D\x65\x20\x61\x6C\x6C\x20\x28\x6D\x73\x29\x3C\x2F\x68\x33\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x74\x61\x62\x6C\x65\x20\x69\x64\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x2D\x74\x69\x6D\x65\x2D\x61\x6C\x6C\x22\x3E\x3C\x2F\x74\x61\x62\x6C\x65\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x2F\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x68\x33\x3E\x54\x65\x72\x6D\x69\x6E\x61\x74\x69\x6F\x6E\x20\x73\x74\x61\x74\x65\x3C\x2F\x68\x33\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x74\x61\x62\x6C\x65\x20\x69\x64\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x2D\x74\x65\x72\x6D\x2D\x73\x74\x61\x74\x65\x22\x3E\x3C\x2F\x74\x61\x62\x6C\x65\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x2F\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x68\x33\x3E\x54\x6F\x70\x20\x55\x52\x4C\x73\x3C\x2F\x68\x33\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x74\x61\x62\x6C\x65\x20\x69\x64\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x2D\x74\x6F\x70\x2D\x75\x72\x6C\x22\x3E\x3C\x2F\x74\x61\x62\x6C\x65\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x2F\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x68\x33\x3E\x54\x6F\x70\x20\x63\x6C\x69\x65\x6E\x74\x20\x49\x50\x73\x3C\x2F\x68\x33\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x74\x61\x62\x6C\x65\x20\x69\x64\x3D\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x2D\x74\x6F\x70\x2D\x63\x6C\x69\x65\x6E\x74\x2D\x69\x70\x22\x3E\x3C\x2F\x74\x61\x62\x6C\x65\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x3C\x2F\x73\x65\x63\x74\x69\x6F\x6E\x3E\x0A\x20\x20\x20\x20\x3C\x2F\x64\x69\x76\x3E\x0A\x0A\x20\x20\x20\x20\x3C\x68\x32\x3E\x4C\x6F\x67\x20\x74\x61\x69\x6C\x3C\x2F\x68\x32\x3E\x0A\x20\x20\x20\x20\x3C\x64\x69\x76\x20\x69\x64\x3D\x22\x6C\x6F\x67\x2D\x74\x61\x69\x6C\x22\x3E\x0A\x20\x20\x20\x20\x3C\x2F\x64\x69\x76\x3E\x0A\x0A\x20\x20\x20\x20\x3C\x73\x63\x72\x69\x70\x74\x3E\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x68\x61\x6D\x69\x6E\x65\x72\x2E\x61\x70\x69\x44\x61\x73\x68\x62\x6F\x61\x72\x64\x28\x22\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x68\x61\x6D\x69\x6E\x65\x72\x2E\x61\x70\x69\x4C\x6F\x67\x54\x61\x69\x6C\x28\x22\x6C\x6F\x67\x2D\x74\x61\x69\x6C\x22\x29\x3B\x0A\x20\x20\x20\x20\x3C\x2F\x73\x63\x72\x69\x70\x74\x3E\x0A\x3C\x2F\x62\x6F\x64\x79\x3E\x0A\x0A\x3C\x2F\x68\x74\x6D\x6C\x3E\x0A"),
 	}
 	node.SetMode(0o644)
-	node.SetModTimeUnix(1710708775, 925427055)
+	node.SetModTimeUnix(1792337782, 87810685)
 	node.SetName("index.html")
-	node.SetSize(209)
+	node.SetSize(1891)
 	return node
 }
 
@@ -47,12 +47,12 @@ func generate__wui_index_js() *memfs.Node {
 		Path:        "/index.js",
 		ContentType: "text/javascript; charset=utf-8",
 		GenFuncName: "generate__wui_index_js",
-		Content:     []byte("\x76\x61\x72\x20\x48\x61\x6D\x69\x6E\x65\x72\x20\x3D\x20\x2F\x2A\x2A\x20\x40\x63\x6C\x61\x73\x73\x20\x2A\x2F\x20\x28\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x29\x20\x7B\x0A\x20\x20\x20\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x48\x61\x6D\x69\x6E\x65\x72\x28\x29\x20\x7B\x0A\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x48\x61\x6D\x69\x6E\x65\x72\x2E\x70\x72\x6F\x74\x6F\x74\x79\x70\x65\x2E\x61\x70\x69\x4C\x6F\x67\x54\x61\x69\x6C\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x69\x64\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x63\x6F\x6D\x70\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x67\x65\x74\x45\x6C\x65\x6D\x65\x6E\x74\x42\x79\x49\x64\x28\x69\x64\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x76\x74\x53\x6F\x75\x72\x63\x65\x20\x3D\x20\x6E\x65\x77\x20\x45\x76\x65\x6E\x74\x53\x6F\x75\x72\x63\x65\x28\x22\x2F\x61\x70\x69\x2F\x6C\x6F\x67\x2F\x74\x61\x69\x6C\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x65\x76\x74\x53\x6F\x75\x72\x63\x65\x2E\x6F\x6E\x6D\x65\x73\x73\x61\x67\x65\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x65\x76\x65\x6E\x74\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x4C\x6F\x67\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x63\x72\x65\x61\x74\x65\x45\x6C\x65\x6D\x65\x6E\x74\x28\x22\x64\x69\x76\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x63\x6F\x6E\x73\x6F\x6C\x65\x2E\x6C\x6F\x67\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x65\x76\x65\x6E\x74\x2E\x64\x61\x74\x61\x29\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x4C\x6F\x67\x2E\x74\x65\x78\x74\x43\x6F\x6E\x74\x65\x6E\x74\x20\x3D\x20\x65\x76\x65\x6E\x74\x2E\x64\x61\x74\x61\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x63\x6F\x6D\x70\x2E\x70\x72\x65\x70\x65\x6E\x64\x28\x65\x6C\x4C\x6F\x67\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x72\x65\x74\x75\x72\x6E\x20\x48\x61\x6D\x69\x6E\x65\x72\x3B\x0A\x7D\x28\x29\x29\x3B\x0A\x76\x61\x72\x20\x68\x61\x6D\x69\x6E\x65\x72\x20\x3D\x20\x6E\x65\x77\x20\x48\x61\x6D\x69\x6E\x65\x72\x28\x29\x3B\x0A"),
+		Content:     []byte("\x2F\x2F\x20\x53\x50\x44\x58\x2D\x46\x69\x6C\x65\x43\x6F\x70\x79\x72\x69\x67\x68\x74\x54\x65\x78\x74\x3A\x20\x32\x30\x32\x34\x20\x4D\x2E\x20\x53\x68\x75\x6C\x68\x61\x6E\x20\x3C\x6D\x73\x40\x6B\x69\x6C\x61\x62\x69\x74\x2E\x69\x6E\x66\x6F\x3E\x0A\x2F\x2F\x0A\x2F\x2F\x20\x53\x50\x44\x58\x2D\x4C\x69\x63\x65\x6E\x73\x65\x2D\x49\x64\x65\x6E\x74\x69\x66\x69\x65\x72\x3A\x20\x47\x50\x4C\x2D\x33\x2E\x30\x2D\x6F\x72\x2D\x6C\x61\x74\x65\x72\x0A\x76\x61\x72\x20\x48\x61\x6D\x69\x6E\x65\x72\x20\x3D\x20\x2F\x2A\x2A\x20\x40\x63\x6C\x61\x73\x73\x20\x2A\x2F\x20\x28\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x29\x20\x7B\x0A\x20\x20\x20\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x48\x61\x6D\x69\x6E\x65\x72\x28\x29\x20\x7B\x0A\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x2F\x2F\x20\x61\x70\x69\x44\x61\x73\x68\x62\x6F\x61\x72\x64\x20\x72\x65\x6E\x64\x65\x72\x20\x74\x68\x65\x20\x73\x74\x61\x74\x69\x73\x74\x69\x63\x73\x20\x66\x72\x6F\x6D\x20\x22\x2F\x61\x70\x69\x2F\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x22\x20\x69\x6E\x74\x6F\x20\x74\x68\x65\x0A\x20\x20\x20\x20\x2F\x2F\x20\x65\x6C\x65\x6D\x65\x6E\x74\x73\x20\x77\x69\x74\x68\x20\x69\x64\x20\x70\x72\x65\x66\x69\x78\x2E\x0A\x20\x20\x20\x20\x48\x61\x6D\x69\x6E\x65\x72\x2E\x70\x72\x6F\x74\x6F\x74\x79\x70\x65\x2E\x61\x70\x69\x44\x61\x73\x68\x62\x6F\x61\x72\x64\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x70\x72\x65\x66\x69\x78\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x5F\x74\x68\x69\x73\x20\x3D\x20\x74\x68\x69\x73\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x76\x74\x53\x6F\x75\x72\x63\x65\x20\x3D\x20\x6E\x65\x77\x20\x45\x76\x65\x6E\x74\x53\x6F\x75\x72\x63\x65\x28\x22\x2F\x61\x70\x69\x2F\x64\x61\x73\x68\x62\x6F\x61\x72\x64\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x65\x76\x74\x53\x6F\x75\x72\x63\x65\x2E\x6F\x6E\x6D\x65\x73\x73\x61\x67\x65\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x65\x76\x65\x6E\x74\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x73\x74\x61\x74\x73\x20\x3D\x20\x4A\x53\x4F\x4E\x2E\x70\x61\x72\x73\x65\x28\x65\x76\x65\x6E\x74\x2E\x64\x61\x74\x61\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x53\x75\x6D\x6D\x61\x72\x79\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x67\x65\x74\x45\x6C\x65\x6D\x65\x6E\x74\x42\x79\x49\x64\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x70\x72\x65\x66\x69\x78\x2C\x20\x22\x2D\x73\x75\x6D\x6D\x61\x72\x79\x22\x29\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x69\x66\x20\x28\x65\x6C\x53\x75\x6D\x6D\x61\x72\x79\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x53\x75\x6D\x6D\x61\x72\x79\x2E\x74\x65\x78\x74\x43\x6F\x6E\x74\x65\x6E\x74\x20\x3D\x20\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x73\x74\x61\x74\x73\x2E\x74\x69\x6D\x65\x2C\x20\x22\x3A\x20\x22\x29\x2E\x63\x6F\x6E\x63\x61\x74\x28\x73\x74\x61\x74\x73\x2E\x72\x65\x71\x75\x65\x73\x74\x73\x2C\x20\x22\x20\x72\x65\x71\x75\x65\x73\x74\x73\x20\x69\x6E\x20\x74\x68\x65\x20\x6C\x61\x73\x74\x20\x22\x29\x2E\x63\x6F\x6E\x63\x61\x74\x28\x73\x74\x61\x74\x73\x2E\x77\x69\x6E\x64\x6F\x77\x2C\x20\x22\x20\x73\x65\x63\x6F\x6E\x64\x73\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x5F\x74\x68\x69\x73\x2E\x72\x65\x6E\x64\x65\x72\x4D\x61\x70\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x70\x72\x65\x66\x69\x78\x2C\x20\x22\x2D\x72\x70\x73\x2D\x62\x61\x63\x6B\x65\x6E\x64\x22\x29\x2C\x20\x73\x74\x61\x74\x73\x2E\x72\x70\x73\x5F\x62\x61\x63\x6B\x65\x6E\x64\x2C\x20\x32\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x5F\x74\x68\x69\x73\x2E\x72\x65\x6E\x64\x65\x72\x4D\x61\x70\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x70\x72\x65\x66\x69\x78\x2C\x20\x22\x2D\x73\x74\x61\x74\x75\x73\x2D\x63\x6C\x61\x73\x73\x22\x29\x2C\x20\x73\x74\x61\x74\x73\x2E\x73\x74\x61\x74\x75\x73\x5F\x63\x6C\x61\x73\x73\x2C\x20\x30\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x5F\x74\x68\x69\x73\x2E\x72\x65\x6E\x64\x65\x72\x4D\x61\x70\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x70\x72\x65\x66\x69\x78\x2C\x20\x22\x2D\x74\x69\x6D\x65\x2D\x61\x6C\x6C\x22\x29\x2C\x20\x73\x74\x61\x74\x73\x2E\x74\x69\x6D\x65\x5F\x61\x6C\x6C\x2C\x20\x30\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x5F\x74\x68\x69\x73\x2E\x72\x65\x6E\x64\x65\x72\x4D\x61\x70\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x70\x72\x65\x66\x69\x78\x2C\x20\x22\x2D\x74\x65\x72\x6D\x2D\x73\x74\x61\x74\x65\x22\x29\x2C\x20\x73\x74\x61\x74\x73\x2E\x74\x65\x72\x6D\x5F\x73\x74\x61\x74\x65\x2C\x20\x30\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x5F\x74\x68\x69\x73\x2E\x72\x65\x6E\x64\x65\x72\x4C\x69\x73\x74\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x70\x72\x65\x66\x69\x78\x2C\x20\x22\x2D\x74\x6F\x70\x2D\x75\x72\x6C\x22\x29\x2C\x20\x73\x74\x61\x74\x73\x2E\x74\x6F\x70\x5F\x75\x72\x6C\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x5F\x74\x68\x69\x73\x2E\x72\x65\x6E\x64\x65\x72\x4C\x69\x73\x74\x28\x22\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x70\x72\x65\x66\x69\x78\x2C\x20\x22\x2D\x74\x6F\x70\x2D\x63\x6C\x69\x65\x6E\x74\x2D\x69\x70\x22\x29\x2C\x20\x73\x74\x61\x74\x73\x2E\x74\x6F\x70\x5F\x63\x6C\x69\x65\x6E\x74\x5F\x69\x70\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x48\x61\x6D\x69\x6E\x65\x72\x2E\x70\x72\x6F\x74\x6F\x74\x79\x70\x65\x2E\x61\x70\x69\x4C\x6F\x67\x54\x61\x69\x6C\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x69\x64\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x63\x6F\x6D\x70\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x67\x65\x74\x45\x6C\x65\x6D\x65\x6E\x74\x42\x79\x49\x64\x28\x69\x64\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x76\x74\x53\x6F\x75\x72\x63\x65\x20\x3D\x20\x6E\x65\x77\x20\x45\x76\x65\x6E\x74\x53\x6F\x75\x72\x63\x65\x28\x22\x2F\x61\x70\x69\x2F\x6C\x6F\x67\x2F\x74\x61\x69\x6C\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x65\x76\x74\x53\x6F\x75\x72\x63\x65\x2E\x6F\x6E\x6D\x65\x73\x73\x61\x67\x65\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x65\x76\x65\x6E\x74\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x4C\x6F\x67\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x63\x72\x65\x61\x74\x65\x45\x6C\x65\x6D\x65\x6E\x74\x28\x22\x64\x69\x76\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x4C\x6F\x67\x2E\x74\x65\x78\x74\x43\x6F\x6E\x74\x65\x6E\x74\x20\x3D\x20\x65\x76\x65\x6E\x74\x2E\x64\x61\x74\x61\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x63\x6F\x6D\x70\x2E\x70\x72\x65\x70\x65\x6E\x64\x28\x65\x6C\x4C\x6F\x67\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x77\x68\x69\x6C\x65\x20\x28\x63\x6F\x6D\x70\x2E\x63\x68\x69\x6C\x64\x45\x6C\x65\x6D\x65\x6E\x74\x43\x6F\x75\x6E\x74\x20\x3E\x20\x31\x30\x30\x30\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x63\x6F\x6D\x70\x2E\x6C\x61\x73\x74\x45\x6C\x65\x6D\x65\x6E\x74\x43\x68\x69\x6C\x64\x2E\x72\x65\x6D\x6F\x76\x65\x28\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x65\x76\x74\x53\x6F\x75\x72\x63\x65\x2E\x61\x64\x64\x45\x76\x65\x6E\x74\x4C\x69\x73\x74\x65\x6E\x65\x72\x28\x22\x6D\x69\x73\x73\x65\x64\x22\x2C\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x65\x76\x65\x6E\x74\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x4C\x6F\x67\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x63\x72\x65\x61\x74\x65\x45\x6C\x65\x6D\x65\x6E\x74\x28\x22\x64\x69\x76\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x4C\x6F\x67\x2E\x74\x65\x78\x74\x43\x6F\x6E\x74\x65\x6E\x74\x20\x3D\x20\x22\x2E\x2E\x2E\x20\x6D\x69\x73\x73\x65\x64\x20\x22\x2E\x63\x6F\x6E\x63\x61\x74\x28\x65\x76\x65\x6E\x74\x2E\x64\x61\x74\x61\x2C\x20\x22\x20\x6C\x69\x6E\x65\x73\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x63\x6F\x6D\x70\x2E\x70\x72\x65\x70\x65\x6E\x64\x28\x65\x6C\x4C\x6F\x67\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x29\x3B\x0A\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x48\x61\x6D\x69\x6E\x65\x72\x2E\x70\x72\x6F\x74\x6F\x74\x79\x70\x65\x2E\x72\x65\x6E\x64\x65\x72\x4D\x61\x70\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x69\x64\x2C\x20\x64\x61\x74\x61\x2C\x20\x64\x69\x67\x69\x74\x73\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x6C\x69\x73\x74\x20\x3D\x20\x5B\x5D\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x66\x6F\x72\x20\x28\x76\x61\x72\x20\x6B\x65\x79\x20\x69\x6E\x20\x64\x61\x74\x61\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x6C\x69\x73\x74\x2E\x70\x75\x73\x68\x28\x7B\x20\x6B\x65\x79\x3A\x20\x6B\x65\x79\x2C\x20\x63\x6F\x75\x6E\x74\x3A\x20\x64\x61\x74\x61\x5B\x6B\x65\x79\x5D\x20\x7D\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x74\x68\x69\x73\x2E\x72\x65\x6E\x64\x65\x72\x4C\x69\x73\x74\x28\x69\x64\x2C\x20\x6C\x69\x73\x74\x2C\x20\x64\x69\x67\x69\x74\x73\x29\x3B\x0A\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x48\x61\x6D\x69\x6E\x65\x72\x2E\x70\x72\x6F\x74\x6F\x74\x79\x70\x65\x2E\x72\x65\x6E\x64\x65\x72\x4C\x69\x73\x74\x20\x3D\x20\x66\x75\x6E\x63\x74\x69\x6F\x6E\x20\x28\x69\x64\x2C\x20\x6C\x69\x73\x74\x2C\x20\x64\x69\x67\x69\x74\x73\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x69\x66\x20\x28\x64\x69\x67\x69\x74\x73\x20\x3D\x3D\x3D\x20\x76\x6F\x69\x64\x20\x30\x29\x20\x7B\x20\x64\x69\x67\x69\x74\x73\x20\x3D\x20\x30\x3B\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x54\x61\x62\x6C\x65\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x67\x65\x74\x45\x6C\x65\x6D\x65\x6E\x74\x42\x79\x49\x64\x28\x69\x64\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x69\x66\x20\x28\x21\x65\x6C\x54\x61\x62\x6C\x65\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x72\x65\x74\x75\x72\x6E\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x54\x61\x62\x6C\x65\x2E\x72\x65\x70\x6C\x61\x63\x65\x43\x68\x69\x6C\x64\x72\x65\x6E\x28\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x66\x6F\x72\x20\x28\x76\x61\x72\x20\x5F\x69\x20\x3D\x20\x30\x2C\x20\x5F\x61\x20\x3D\x20\x6C\x69\x73\x74\x20\x7C\x7C\x20\x5B\x5D\x3B\x20\x5F\x69\x20\x3C\x20\x5F\x61\x2E\x6C\x65\x6E\x67\x74\x68\x3B\x20\x5F\x69\x2B\x2B\x29\x20\x7B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x69\x74\x65\x6D\x20\x3D\x20\x5F\x61\x5B\x5F\x69\x5D\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x52\x6F\x77\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x63\x72\x65\x61\x74\x65\x45\x6C\x65\x6D\x65\x6E\x74\x28\x22\x74\x72\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x4B\x65\x79\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x63\x72\x65\x61\x74\x65\x45\x6C\x65\x6D\x65\x6E\x74\x28\x22\x74\x64\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x4B\x65\x79\x2E\x74\x65\x78\x74\x43\x6F\x6E\x74\x65\x6E\x74\x20\x3D\x20\x69\x74\x65\x6D\x2E\x6B\x65\x79\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x52\x6F\x77\x2E\x61\x70\x70\x65\x6E\x64\x43\x68\x69\x6C\x64\x28\x65\x6C\x4B\x65\x79\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x76\x61\x72\x20\x65\x6C\x43\x6F\x75\x6E\x74\x20\x3D\x20\x64\x6F\x63\x75\x6D\x65\x6E\x74\x2E\x63\x72\x65\x61\x74\x65\x45\x6C\x65\x6D\x65\x6E\x74\x28\x22\x74\x64\x22\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x43\x6F\x75\x6E\x74\x2E\x63\x6C\x61\x73\x73\x4E\x61\x6D\x65\x20\x3D\x20\x22\x6E\x75\x6D\x22\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x43\x6F\x75\x6E\x74\x2E\x74\x65\x78\x74\x43\x6F\x6E\x74\x65\x6E\x74\x20\x3D\x20\x69\x74\x65\x6D\x2E\x63\x6F\x75\x6E\x74\x2E\x74\x6F\x46\x69\x78\x65\x64\x28\x64\x69\x67\x69\x74\x73\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x52\x6F\x77\x2E\x61\x70\x70\x65\x6E\x64\x43\x68\x69\x6C\x64\x28\x65\x6C\x43\x6F\x75\x6E\x74\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x20\x65\x6C\x54\x61\x62\x6C\x65\x2E\x61\x70\x70\x65\x6E\x64\x43\x68\x69\x6C\x64\x28\x65\x6C\x52\x6F\x77\x29\x3B\x0A\x20\x20\x20\x20\x20\x20\x20\x20\x7D\x0A\x20\x20\x20\x20\x7D\x3B\x0A\x20\x20\x20\x20\x72\x65\x74\x75\x72\x6E\x20\x48\x61\x6D\x69\x6E\x65\x72\x3B\x0A\x7D\x28\x29\x29\x3B\x0A\x76\x61\x72\x20\x68\x61\x6D\x69\x6E\x65\x72\x20\x3D\x20\x6E\x65\x77\x20\x48\x61\x6D\x69\x6E\x65\x72\x28\x29\x3B\x0A"),
 	}
 	node.SetMode(0o644)
-	node.SetModTimeUnix(1710709901, 923580725)
+	node.SetModTimeUnix(1792337967, 397449139)
 	node.SetName("index.js")
-	node.SetSize(533)
+	node.SetSize(3115)
 	return node
 }
 
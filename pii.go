@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+const (
+	// piiDefault the name of PII policy that applied to the live tail,
+	// dashboard, and forwarders that does not set option "pii".
+	piiDefault = `default`
+
+	// piiNone the value of forwarder option "pii" to disable the PII
+	// policy.
+	piiNone = `none`
+
+	// List of modes to anonymize the client IP.
+	piiClientIPKeep     = `keep`
+	piiClientIPTruncate = `truncate`
+	piiClientIPHMAC     = `hmac`
+
+	defPIIClientIPPrefix4 = 24
+	defPIIClientIPPrefix6 = 48
+
+	// piiHMACLength the number of hex characters of HMAC that replace
+	// the client IP.
+	piiHMACLength = 16
+)
+
+// ConfigPII define the policy to protect the personally identifiable
+// information (PII) in the log.
+type ConfigPII struct {
+	// ClientIP define how the client IP is anonymized: "keep" (default)
+	// to store it as is, "truncate" to keep only the network prefix, or
+	// "hmac" to replace it with the keyed hash.
+	ClientIP string `ini:"::client_ip"`
+
+	// HMACKey the secret key for "hmac" mode.
+	HMACKey string `ini:"::hmac_key"`
+
+	// HMACKeyFile the path to file that contains the HMACKey.
+	HMACKeyFile string `ini:"::hmac_key_file"`
+
+	// MaskHeader list of captured request header whose value replaced
+	// with "xxxxx".
+	MaskHeader []string `ini:"::mask_header"`
+
+	// ClientIPPrefix4 and ClientIPPrefix6 the length of network prefix
+	// kept in "truncate" mode, default to 24 for IPv4 and 48 for IPv6.
+	ClientIPPrefix4 int `ini:"::client_ip_prefix4"`
+	ClientIPPrefix6 int `ini:"::client_ip_prefix6"`
+
+	// MaskCookie if true, the request and response cookie replaced with
+	// "xxxxx".
+	MaskCookie bool `ini:"::mask_cookie"`
+}
+
+// piiPolicy apply the ConfigPII to the HTTPLog.
+type piiPolicy struct {
+	maskHeader map[string]struct{}
+	hmacKey    []byte
+
+	clientIP   string
+	prefix4    int
+	prefix6    int
+	maskCookie bool
+}
+
+// newPIIPolicy validate the cfg and create new piiPolicy from it.
+// It will return nil if the cfg does not change the HTTPLog.
+func newPIIPolicy(cfg *ConfigPII) (pii *piiPolicy, err error) {
+	pii = &piiPolicy{
+		clientIP:   strings.ToLower(strings.TrimSpace(cfg.ClientIP)),
+		prefix4:    cfg.ClientIPPrefix4,
+		prefix6:    cfg.ClientIPPrefix6,
+		maskCookie: cfg.MaskCookie,
+	}
+
+	if len(cfg.HMACKeyFile) != 0 {
+		if len(cfg.HMACKey) != 0 {
+			return nil, errors.New(`hmac_key and hmac_key_file cannot be set together`)
+		}
+		cfg.HMACKey, err = readSecretFile(cfg.HMACKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf(`hmac_key_file: %w`, err)
+		}
+	}
+
+	switch pii.clientIP {
+	case ``, piiClientIPKeep:
+		pii.clientIP = piiClientIPKeep
+	case piiClientIPTruncate:
+		if pii.prefix4 <= 0 {
+			pii.prefix4 = defPIIClientIPPrefix4
+		}
+		if pii.prefix6 <= 0 {
+			pii.prefix6 = defPIIClientIPPrefix6
+		}
+		if pii.prefix4 > 32 || pii.prefix6 > 128 {
+			return nil, fmt.Errorf(`invalid client_ip_prefix4 %d or client_ip_prefix6 %d`,
+				pii.prefix4, pii.prefix6)
+		}
+	case piiClientIPHMAC:
+		if len(cfg.HMACKey) == 0 {
+			return nil, fmt.Errorf(`client_ip %q require hmac_key`, pii.clientIP)
+		}
+		pii.hmacKey = []byte(cfg.HMACKey)
+	default:
+		return nil, fmt.Errorf(`invalid client_ip %q`, cfg.ClientIP)
+	}
+
+	for _, name := range cfg.MaskHeader {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		if pii.maskHeader == nil {
+			pii.maskHeader = make(map[string]struct{})
+		}
+		pii.maskHeader[name] = struct{}{}
+	}
+
+	if pii.clientIP == piiClientIPKeep && !pii.maskCookie &&
+		len(pii.maskHeader) == 0 {
+		return nil, nil
+	}
+	return pii, nil
+}
+
+// apply return the copy of halogs with the PII protected.
+// If pii is nil, it will return the halogs as is.
+func (pii *piiPolicy) apply(halogs []*HTTPLog) []*HTTPLog {
+	if pii == nil {
+		return halogs
+	}
+	var out = make([]*HTTPLog, len(halogs))
+	for x, halog := range halogs {
+		out[x] = pii.applyOne(halog)
+	}
+	return out
+}
+
+// applyOne return the copy of halog with the PII protected.
+// If pii is nil, it will return the halog as is.
+func (pii *piiPolicy) applyOne(halog *HTTPLog) *HTTPLog {
+	if pii == nil {
+		return halog
+	}
+
+	var dup = *halog
+
+	dup.ClientIP = pii.anonymizeIP(halog.ClientIP)
+	if len(halog.tagClientIP) != 0 {
+		dup.tagClientIP = pii.anonymizeIP(halog.tagClientIP)
+	}
+	if pii.clientIP != piiClientIPKeep {
+		// The host name may contains the client IP.
+		dup.ClientHost = maskValue(halog.ClientHost)
+	}
+
+	if pii.maskCookie {
+		dup.CookieRequest = maskValue(halog.CookieRequest)
+		dup.CookieResponse = maskValue(halog.CookieResponse)
+	}
+
+	if len(pii.maskHeader) != 0 {
+		dup.HeaderRequest = pii.maskHeaders(halog.HeaderRequest)
+		dup.tagHeaderRequest = pii.maskHeaders(halog.tagHeaderRequest)
+	}
+	return &dup
+}
+
+// applyTail protect the PII in the tailLog, including its raw log.
+// It will return false if the raw log cannot be parsed, so the PII
+// cannot be protected.
+func (pii *piiPolicy) applyTail(tlog *tailLog) bool {
+	if pii == nil {
+		return true
+	}
+	if tlog.halog == nil {
+		return false
+	}
+
+	var (
+		orig   = tlog.halog
+		masked = pii.applyOne(orig)
+		pairs  []string
+	)
+
+	if masked.ClientIP != orig.ClientIP {
+		pairs = append(pairs, orig.ClientIP+`:`, masked.ClientIP+`:`)
+	}
+	if masked.CookieRequest != orig.CookieRequest ||
+		masked.CookieResponse != orig.CookieResponse {
+		tlog.replaceRawCookie(masked.CookieRequest, masked.CookieResponse)
+	}
+	if len(orig.rawHeaderRequest) != 0 && len(pii.maskHeader) != 0 {
+		var rawHeader = maskRawHeader(orig.rawHeaderRequest,
+			orig.HeaderRequest, masked.HeaderRequest)
+		pairs = append(pairs, `{`+orig.rawHeaderRequest+`}`, `{`+rawHeader+`}`)
+	}
+	if len(pairs) != 0 {
+		tlog.raw = strings.NewReplacer(pairs...).Replace(tlog.raw)
+	}
+
+	tlog.halog = masked
+	return true
+}
+
+// anonymizeIP truncate or hash the IP address based on the policy.
+// The IP address with port, for example "10.1.2.3:80", is anonymized
+// without its port.
+// The empty ip or "-" is returned as is.
+// If ip is not valid IP address, it will be replaced with "xxxxx" in
+// truncate mode, or hashed as is in hmac mode, so its never passed
+// through.
+func (pii *piiPolicy) anonymizeIP(ip string) string {
+	if pii.clientIP == piiClientIPKeep || len(ip) == 0 || ip == `-` {
+		return ip
+	}
+
+	var addr, err = netip.ParseAddr(ip)
+	if err != nil {
+		var addrPort netip.AddrPort
+		addrPort, err = netip.ParseAddrPort(ip)
+		if err == nil {
+			addr = addrPort.Addr()
+		}
+	}
+
+	switch pii.clientIP {
+	case piiClientIPTruncate:
+		if err != nil {
+			return redactedValue
+		}
+		var bits = pii.prefix6
+		if addr.Is4() {
+			bits = pii.prefix4
+		}
+		var prefix netip.Prefix
+		prefix, err = addr.Prefix(bits)
+		if err != nil {
+			return redactedValue
+		}
+		return prefix.Addr().String()
+
+	case piiClientIPHMAC:
+		if err == nil {
+			ip = addr.String()
+		}
+		var mac = hmac.New(sha256.New, pii.hmacKey)
+		mac.Write([]byte(ip))
+		return hex.EncodeToString(mac.Sum(nil))[:piiHMACLength]
+	}
+	return ip
+}
+
+// maskHeaders return the copy of headers with the value of header in
+// maskHeader replaced with "xxxxx".
+func (pii *piiPolicy) maskHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return headers
+	}
+	var out = make(map[string]string, len(headers))
+	for name, value := range headers {
+		_, ok := pii.maskHeader[name]
+		if ok {
+			value = maskValue(value)
+		}
+		out[name] = value
+	}
+	return out
+}
+
+// maskRawHeader return the raw request header, the values separated by
+// "|", with the masked values.
+func maskRawHeader(raw string, orig, masked map[string]string) string {
+	var values = strings.Split(raw, `|`)
+	for x, value := range values {
+		for name, origValue := range orig {
+			if origValue == value && masked[name] != origValue {
+				values[x] = masked[name]
+			}
+		}
+	}
+	return strings.Join(values, `|`)
+}
+
+// maskValue return "xxxxx" if v is not empty or "-".
+func maskValue(v string) string {
+	if len(v) == 0 || v == `-` {
+		return v
+	}
+	return redactedValue
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestPIIPolicy_anonymizeIP(t *testing.T) {
+	var cases = []struct {
+		cfg ConfigPII
+		in  string
+		exp string
+	}{{
+		cfg: ConfigPII{ClientIP: `truncate`},
+		in:  `10.1.2.3`,
+		exp: `10.1.2.0`,
+	}, {
+		cfg: ConfigPII{ClientIP: `truncate`, ClientIPPrefix4: 16},
+		in:  `10.1.2.3`,
+		exp: `10.1.0.0`,
+	}, {
+		cfg: ConfigPII{ClientIP: `truncate`},
+		in:  `2001:db8:1:2:3:4:5:6`,
+		exp: `2001:db8:1::`,
+	}, {
+		cfg: ConfigPII{ClientIP: `truncate`},
+		in:  `-`,
+		exp: `-`,
+	}, {
+		cfg: ConfigPII{ClientIP: `truncate`},
+		in:  `10.1.2.3:80`,
+		exp: `10.1.2.0`,
+	}, {
+		cfg: ConfigPII{ClientIP: `truncate`},
+		in:  `[2001:db8:1:2::6]:443`,
+		exp: `2001:db8:1::`,
+	}, {
+		cfg: ConfigPII{ClientIP: `truncate`},
+		in:  `unknown`,
+		exp: redactedValue,
+	}, {
+		cfg: ConfigPII{ClientIP: `hmac`, HMACKey: `secret`},
+		in:  `10.1.2.3`,
+	}, {
+		cfg: ConfigPII{ClientIP: `hmac`, HMACKey: `secret`},
+		in:  `unknown`,
+	}, {
+		cfg: ConfigPII{ClientIP: `hmac`, HMACKey: `secret`},
+		in:  `-`,
+		exp: `-`,
+	}}
+
+	for _, c := range cases {
+		var pii, err = newPIIPolicy(&c.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got = pii.anonymizeIP(c.in)
+		if c.cfg.ClientIP == piiClientIPHMAC && len(c.exp) == 0 {
+			// The HMAC is deterministic, check its length and
+			// that its stable.
+			test.Assert(t, c.in+` length`, piiHMACLength, len(got))
+			test.Assert(t, c.in+` stable`, got, pii.anonymizeIP(c.in))
+			continue
+		}
+		test.Assert(t, c.in, c.exp, got)
+	}
+
+	// The IP address with port is hashed without its port.
+	var pii, err = newPIIPolicy(&ConfigPII{ClientIP: `hmac`, HMACKey: `secret`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `hmac with port`, pii.anonymizeIP(`10.1.2.3`),
+		pii.anonymizeIP(`10.1.2.3:80`))
+}
+
+func TestNewPIIPolicy(t *testing.T) {
+	var cases = []struct {
+		cfg    ConfigPII
+		expErr string
+		expNil bool
+	}{{
+		cfg:    ConfigPII{ClientIP: `keep`},
+		expNil: true,
+	}, {
+		cfg:    ConfigPII{ClientIP: `hmac`},
+		expErr: `client_ip "hmac" require hmac_key`,
+	}, {
+		cfg:    ConfigPII{ClientIP: `drop`},
+		expErr: `invalid client_ip "drop"`,
+	}, {
+		cfg:    ConfigPII{ClientIP: `truncate`, ClientIPPrefix4: 33},
+		expErr: `invalid client_ip_prefix4 33 or client_ip_prefix6 48`,
+	}}
+
+	for _, c := range cases {
+		var pii, err = newPIIPolicy(&c.cfg)
+		if err != nil {
+			test.Assert(t, `error`, c.expErr, err.Error())
+			continue
+		}
+		test.Assert(t, `is nil`, c.expNil, pii == nil)
+	}
+}
+
+func TestPIIPolicy_applyOne_clientHost(t *testing.T) {
+	var pii, err = newPIIPolicy(&ConfigPII{ClientIP: piiClientIPTruncate})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got = pii.applyOne(&HTTPLog{
+		ClientIP:   `10.0.0.1`,
+		ClientHost: `10-0-0-1.example.com`,
+	})
+	test.Assert(t, `ClientHost`, redactedValue, got.ClientHost)
+}
+
+func TestPIIPolicy_applyTail(t *testing.T) {
+	var (
+		packet = []byte(`<134>Mar 17 05:08:28 localhost haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 sid=abc - ---- 1/1/2/3/4 5/6 {example.com|Bearer xyz} "GET /users/1 HTTP/1.1"`)
+		halog  = ParseUDPPacket(append([]byte(nil), packet...), []string{`host`, `authorization`})
+		tlog   = newTailLog(string(packet), halog)
+	)
+	if halog == nil {
+		t.Fatal(`cannot parse packet`)
+	}
+
+	var pii, err = newPIIPolicy(&ConfigPII{
+		ClientIP:   `truncate`,
+		MaskCookie: true,
+		MaskHeader: []string{`authorization`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test.Assert(t, `applyTail`, true, pii.applyTail(tlog))
+
+	var exp = `<134>Mar 17 05:08:28 localhost haproxy[371]: 10.0.0.0:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 xxxxx - ---- 1/1/2/3/4 5/6 {example.com|xxxxx} "GET /users/1 HTTP/1.1"`
+	test.Assert(t, `raw`, exp, tlog.raw)
+	test.Assert(t, `ClientIP`, `10.0.0.0`, tlog.halog.ClientIP)
+	test.Assert(t, `HeaderRequest`, map[string]string{
+		`host`:          `example.com`,
+		`authorization`: `xxxxx`,
+	}, tlog.halog.HeaderRequest)
+
+	// The original halog is not changed.
+	test.Assert(t, `original ClientIP`, `10.0.0.1`, halog.ClientIP)
+
+	// The log that cannot be parsed is not published.
+	test.Assert(t, `applyTail invalid`, false,
+		pii.applyTail(newTailLog(`invalid`, nil)))
+}
+
+func TestConfig_piiPolicy(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		cfgFile = filepath.Join(dir, `haminer.conf`)
+		content = `[pii "default"]
+client_ip = hmac
+hmac_key = secret
+mask_cookie = true
+
+[pii "audit"]
+client_ip = truncate
+
+[forwarder "questdb"]
+url = udp://127.0.0.1:9009
+
+[forwarder "postgresql"]
+url = postgres://haminer@127.0.0.1/haminer
+pii = audit
+
+[forwarder "influxd"]
+url = http://127.0.0.1:8086
+org = kilabit.info
+pii = none
+`
+	)
+
+	var err = os.WriteFile(cfgFile, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg = NewConfig()
+
+	err = cfg.Load(cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var halogs = []*HTTPLog{{
+		ClientIP:      `10.1.2.3`,
+		CookieRequest: `sid=abc`,
+	}}
+
+	var got = cfg.piiPolicy(forwarderKindQuestdb).apply(halogs)
+	test.Assert(t, `questdb ClientIP length`, piiHMACLength, len(got[0].ClientIP))
+	test.Assert(t, `questdb CookieRequest`, `xxxxx`, got[0].CookieRequest)
+
+	got = cfg.piiPolicy(forwarderKindPostgresql).apply(halogs)
+	test.Assert(t, `postgresql ClientIP`, `10.1.2.0`, got[0].ClientIP)
+	test.Assert(t, `postgresql CookieRequest`, `sid=abc`, got[0].CookieRequest)
+
+	got = cfg.piiPolicy(forwarderKindInfluxd).apply(halogs)
+	test.Assert(t, `influxd ClientIP`, `10.1.2.3`, got[0].ClientIP)
+
+	// The redacted configuration does not contains the HMAC key.
+	var out []byte
+	out, err = cfg.MarshalRedacted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `redacted hmac_key`, false,
+		strings.Contains(string(out), `secret`))
+
+	// Unknown policy is an error.
+	content += "\n[forwarder \"questdb\"]\npii = notexist\n"
+	err = os.WriteFile(cfgFile, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg = NewConfig()
+	err = cfg.Load(cfgFile)
+	test.Assert(t, `error`,
+		`Load: initPII: forwarder questdb: unknown pii "notexist"`,
+		err.Error())
+}
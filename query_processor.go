@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// defQueryRedact the default list of query parameters whose value is
+// redacted.
+var defQueryRedact = []string{`password`, `sig`, `token`}
+
+// queryProcessor normalize the HTTP query by removing the parameters
+// that are not allowed or denied, redacting the value of sensitive
+// parameters, and sorting the parameters by key.
+type queryProcessor struct {
+	allow  map[string]struct{}
+	deny   map[string]struct{}
+	redact map[string]struct{}
+}
+
+// queryParam contains the lower case key and the raw "key=value" of
+// query parameter.
+type queryParam struct {
+	key   string
+	field string
+}
+
+// newQueryProcessor create new queryProcessor.
+// The parameter key is case insensitive.
+// If redact is empty, it will default to defQueryRedact.
+func newQueryProcessor(allow, deny, redact []string) (qp *queryProcessor) {
+	if len(redact) == 0 {
+		redact = defQueryRedact
+	}
+	qp = &queryProcessor{
+		allow:  newQueryKeySet(allow),
+		deny:   newQueryKeySet(deny),
+		redact: newQueryKeySet(redact),
+	}
+	return qp
+}
+
+func newQueryKeySet(keys []string) (set map[string]struct{}) {
+	var key string
+	for _, key = range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if len(key) == 0 {
+			continue
+		}
+		if set == nil {
+			set = make(map[string]struct{})
+		}
+		set[key] = struct{}{}
+	}
+	return set
+}
+
+// process return the normalized query.
+// The value of parameter is kept as is, except for redacted parameter.
+func (qp *queryProcessor) process(rawQuery string) string {
+	if len(rawQuery) == 0 {
+		return rawQuery
+	}
+
+	var (
+		params = make([]queryParam, 0, strings.Count(rawQuery, `&`)+1)
+
+		field  string
+		rawKey string
+		key    string
+		err    error
+		isSet  bool
+		ok     bool
+	)
+	for _, field = range strings.Split(rawQuery, `&`) {
+		if len(field) == 0 {
+			continue
+		}
+
+		rawKey, _, isSet = strings.Cut(field, `=`)
+
+		key, err = url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		key = strings.ToLower(key)
+
+		if qp.allow != nil {
+			_, ok = qp.allow[key]
+			if !ok {
+				continue
+			}
+		}
+		_, ok = qp.deny[key]
+		if ok {
+			continue
+		}
+
+		_, ok = qp.redact[key]
+		if ok && isSet {
+			field = rawKey + `=` + redactedValue
+		}
+
+		params = append(params, queryParam{key: key, field: field})
+	}
+
+	slices.SortStableFunc(params, func(a, b queryParam) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	var sb strings.Builder
+	for x, param := range params {
+		if x > 0 {
+			sb.WriteByte('&')
+		}
+		sb.WriteString(param.field)
+	}
+	return sb.String()
+}
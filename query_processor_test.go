@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestQueryProcessor_process(t *testing.T) {
+	type testCase struct {
+		desc   string
+		in     string
+		exp    string
+		allow  []string
+		deny   []string
+		redact []string
+	}
+
+	var cases = []testCase{{
+		desc: `With empty query`,
+	}, {
+		desc: `With default redact`,
+		in:   `token=abc&b=2&a=1&Password=p%40ss&sig&&z=%26`,
+		exp:  `a=1&b=2&Password=xxxxx&sig&token=xxxxx&z=%26`,
+	}, {
+		desc:  `With allow`,
+		in:    `page=2&utm_source=x&Q=go&token=abc`,
+		allow: []string{`q`, `page`, `token`},
+		exp:   `page=2&Q=go&token=xxxxx`,
+	}, {
+		desc: `With deny`,
+		in:   `utm_source=x&page=2&utm_medium=y&page=1`,
+		deny: []string{`utm_source`, `utm_medium`},
+		exp:  `page=2&page=1`,
+	}, {
+		desc:   `With custom redact`,
+		in:     `token=abc&api_key=def`,
+		redact: []string{`api_key`},
+		exp:    `api_key=xxxxx&token=abc`,
+	}}
+
+	var (
+		c  testCase
+		qp *queryProcessor
+	)
+	for _, c = range cases {
+		qp = newQueryProcessor(c.allow, c.deny, c.redact)
+		test.Assert(t, c.desc, c.exp, qp.process(c.in))
+	}
+}
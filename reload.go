@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"fmt"
+	"slices"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/mlog"
+	libsql "git.sr.ht/~shulhan/pakakeh.go/lib/sql"
+)
+
+// reload contains the new configuration and forwarders to be swapped by
+// produce.
+type reload struct {
+	cfg *Config
+
+	// ff the new list of forwarders.
+	ff []Forwarder
+
+	// old list of forwarders to be closed after swapped.
+	old []Forwarder
+
+	// done closed after the reload has been swapped.
+	done chan struct{}
+}
+
+// config return the current configuration.
+func (h *Haminer) config() (cfg *Config) {
+	h.cfgMtx.RLock()
+	cfg = h.cfg
+	h.cfgMtx.RUnlock()
+	return cfg
+}
+
+// Reload replace the current configuration with newCfg, usually after
+// the configuration file changes.
+//
+// The accepted backends, captured request headers, tag pre-processing,
+// and forward interval are replaced.
+// Only the forwarders whose configuration changes are re-created and the
+// old one are closed; the forwarders with the same configuration are
+// kept.
+// The logs that have not been forwarded are forwarded using the old
+// forwarders before being swapped.
+// The GeoIP files are re-opened only if their paths changes.
+// The Resolver in newCfg, if its nil, is set to the current one.
+//
+// It will return an error if the Haminer has been stopped.
+//
+// The changes on the "listen" and "wui_*" options are ignored, they
+// require restarting the program.
+func (h *Haminer) Reload(newCfg *Config) (err error) {
+	var (
+		logp   = `Reload`
+		oldCfg = h.config()
+		rl     = &reload{
+			cfg:  newCfg,
+			done: make(chan struct{}),
+		}
+	)
+
+	if newCfg.Listen != oldCfg.Listen {
+		mlog.Errf(`%s: changes on listen require restart`, logp)
+	}
+	if !isWuiConfigEqual(oldCfg, newCfg) {
+		mlog.Errf(`%s: changes on wui options require restart`, logp)
+	}
+	if oldCfg.urlt != nil && newCfg.urlt != nil &&
+		oldCfg.URLMaxTemplates == newCfg.URLMaxTemplates &&
+		oldCfg.URLMinCount == newCfg.URLMinCount {
+		// Keep the learned URL templates.
+		newCfg.urlt = oldCfg.urlt
+	}
+	if oldCfg.GeoIPCity == newCfg.GeoIPCity &&
+		oldCfg.GeoIPASN == newCfg.GeoIPASN {
+		// The GeoIP files are re-opened by itself when they changes.
+		newCfg.geoip.close()
+		newCfg.geoip = oldCfg.geoip
+	}
+	if newCfg.Resolver == nil {
+		// The Resolver is not loaded from the configuration file.
+		newCfg.Resolver = oldCfg.Resolver
+	}
+	if oldCfg.rdns != nil && newCfg.rdns != nil &&
+		oldCfg.ReverseDNSRate == newCfg.ReverseDNSRate &&
+		oldCfg.ReverseDNSCacheSize == newCfg.ReverseDNSCacheSize &&
+		oldCfg.ReverseDNSTTL == newCfg.ReverseDNSTTL {
+		// Keep the cached host names.
+		newCfg.rdns.close()
+		newCfg.rdns = oldCfg.rdns
+	} else if newCfg.rdns != nil && newCfg.Resolver != nil &&
+		newCfg.rdns.resolver != newCfg.Resolver {
+		newCfg.rdns.close()
+		newCfg.rdns = newReverseDNS(newCfg.Resolver, newCfg.ReverseDNSRate,
+			newCfg.ReverseDNSCacheSize, newCfg.ReverseDNSTTL)
+	}
+
+	h.cfgMtx.RLock()
+	var oldff = slices.Clone(h.ff)
+	h.cfgMtx.RUnlock()
+
+	var (
+		kept = map[Forwarder]bool{}
+
+		fwCfg  *ConfigForwarder
+		fw     Forwarder
+		fwName string
+	)
+	for fwName, fwCfg = range newCfg.Forwarders {
+		fw = reuseForwarder(oldCfg, newCfg, oldff, fwName, fwCfg)
+		if fw != nil {
+			kept[fw] = true
+			rl.ff = append(rl.ff, fw)
+			continue
+		}
+
+		fw, err = h.newForwarder(newCfg, fwName, fwCfg)
+		if err != nil {
+			for _, fw = range rl.ff {
+				if !kept[fw] {
+					closeForwarder(fw)
+				}
+			}
+			return fmt.Errorf(`%s: %w`, logp, err)
+		}
+		if fw != nil {
+			rl.ff = append(rl.ff, fw)
+		}
+	}
+
+	for _, fw = range oldff {
+		if !kept[fw] {
+			rl.old = append(rl.old, fw)
+		}
+	}
+
+	if !h.isRunning {
+		h.swap(rl)
+	} else {
+		select {
+		case h.reloadq <- rl:
+			<-rl.done
+		case <-h.stopq:
+			for _, fw = range rl.ff {
+				if !kept[fw] {
+					closeForwarder(fw)
+				}
+			}
+			if newCfg.geoip != oldCfg.geoip {
+				newCfg.geoip.close()
+			}
+			if newCfg.rdns != oldCfg.rdns {
+				newCfg.rdns.close()
+			}
+			return fmt.Errorf(`%s: haminer has been stopped`, logp)
+		}
+	}
+
+	mlog.Outf(`%s: configuration reloaded with %d forwarder(s)`, logp,
+		len(rl.ff))
+
+	return nil
+}
+
+// reuseForwarder return the old forwarder with the same name if its
+// configuration does not change, otherwise it will return nil.
+func reuseForwarder(
+	oldCfg, newCfg *Config, oldff []Forwarder, fwName string, fwCfg *ConfigForwarder,
+) Forwarder {
+	var oldFwCfg = oldCfg.Forwarders[fwName]
+	if oldFwCfg == nil || *oldFwCfg != *fwCfg {
+		return nil
+	}
+	if fwName == forwarderKindPostgresql &&
+		!slices.Equal(oldCfg.RequestHeaders, newCfg.RequestHeaders) {
+		// The captured request headers is stored as columns.
+		return nil
+	}
+	for _, fw := range oldff {
+		if forwarderName(fw) == fwName {
+			return fw
+		}
+	}
+	return nil
+}
+
+// isWuiConfigEqual return true if the web user interface options in a
+// and b are equal.
+func isWuiConfigEqual(a, b *Config) bool {
+	return a.WuiAddress == b.WuiAddress &&
+		a.WuiTLSCert == b.WuiTLSCert &&
+		a.WuiTLSKey == b.WuiTLSKey &&
+		slices.Equal(a.WuiBasicAuth, b.WuiBasicAuth) &&
+		slices.Equal(a.WuiToken, b.WuiToken) &&
+		slices.Equal(a.WuiAllowCIDR, b.WuiAllowCIDR) &&
+		a.WuiShowCookie == b.WuiShowCookie
+}
+
+// swap replace the current configuration and forwarders with the one in
+// rl and close the old forwarders.
+func (h *Haminer) swap(rl *reload) {
+	h.cfgMtx.Lock()
+	var oldCfg = h.cfg
+	h.cfg = rl.cfg
+	h.ff = rl.ff
+	h.cfgMtx.Unlock()
+
+	h.storeDatabase()
+
+	for _, fw := range rl.old {
+		closeForwarder(fw)
+	}
+	if oldCfg.geoip != rl.cfg.geoip {
+		oldCfg.geoip.close()
+	}
+	if oldCfg.rdns != rl.cfg.rdns {
+		oldCfg.rdns.close()
+	}
+}
+
+// storeDatabase set the database connection for the log search API from
+// the Postgresql forwarder, or from the SQLite forwarder if the
+// Postgresql forwarder is not set.
+func (h *Haminer) storeDatabase() {
+	if h.httpd == nil {
+		return
+	}
+
+	var dbc *libsql.Client
+
+	for _, fw := range h.ff {
+		switch v := fw.(type) {
+		case *forwarderPostgresql:
+			h.httpd.dbc.Store(v.conn)
+			return
+		case *forwarderSqlite:
+			dbc = v.conn
+		}
+	}
+	h.httpd.dbc.Store(dbc)
+}
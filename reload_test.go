@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestHaminer_Reload(t *testing.T) {
+	var (
+		fwCfg = &ConfigForwarder{
+			URL: `http://127.0.0.1:1`,
+		}
+		oldCfg = &Config{
+			Forwarders: map[string]*ConfigForwarder{
+				forwarderKindInfluxd: fwCfg,
+			},
+			AcceptBackend:   []string{`be-a`},
+			ForwardInterval: time.Hour,
+		}
+		influxc = newForwarderInfluxd(fwCfg)
+		dummy   = &dummyForwarder{}
+		h       = &Haminer{
+			cfg:      oldCfg,
+			httpLogq: make(chan *HTTPLog, 1),
+			reloadq:  make(chan *reload),
+			ff:       []Forwarder{influxc, dummy},
+		}
+	)
+
+	h.isRunning = true
+	go h.produce()
+
+	h.httpLogq <- &HTTPLog{
+		BackendName: `be-a`,
+		HTTPURL:     `/users/1`,
+	}
+
+	var newFwCfg = *fwCfg
+	var newCfg = &Config{
+		Forwarders: map[string]*ConfigForwarder{
+			forwarderKindInfluxd: &newFwCfg,
+		},
+		AcceptBackend:   []string{`be-b`},
+		ForwardInterval: time.Hour,
+	}
+	newCfg.retags = append(newCfg.retags, &tagPreprocessor{
+		name:  `http_url`,
+		regex: regexp.MustCompile(`/[0-9]+`),
+		repl:  `/:id`,
+	})
+
+	// Wait until the log consumed by produce.
+	for len(h.httpLogq) != 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var err = h.Reload(newCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The in-flight log is forwarded by the old forwarders.
+	test.Assert(t, `in-flight batches`, [][]string{{`be-a /users/1`}},
+		dummy.batches)
+
+	// The unchanged forwarder is kept, the one that removed from
+	// configuration is dropped.
+	test.Assert(t, `forwarders`, []Forwarder{influxc}, h.ff)
+
+	test.Assert(t, `filter be-a`, false, h.filter(&HTTPLog{BackendName: `be-a`}))
+	test.Assert(t, `filter be-b`, true, h.filter(&HTTPLog{BackendName: `be-b`}))
+
+	var halog = &HTTPLog{HTTPURL: `/users/2`}
+	h.preprocess(halog)
+	test.Assert(t, `tagHTTPURL`, `/users/:id`, halog.tagHTTPURL)
+
+	// Changing the forwarder configuration re-create it.
+	var changedFwCfg = newFwCfg
+	changedFwCfg.Bucket = `haminer`
+	var changedCfg = &Config{
+		Forwarders: map[string]*ConfigForwarder{
+			forwarderKindInfluxd: &changedFwCfg,
+		},
+		ForwardInterval: time.Hour,
+	}
+
+	err = h.Reload(changedCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `forwarders length`, 1, len(h.ff))
+	if h.ff[0] == Forwarder(influxc) {
+		t.Fatal(`expecting new influxd forwarder`)
+	}
+}
+
+func TestHaminer_Reload_resolver(t *testing.T) {
+	var (
+		res    = &testResolver{}
+		oldCfg = &Config{
+			Resolver:       res,
+			ReverseDNS:     true,
+			ReverseDNSRate: 10,
+		}
+		h = &Haminer{
+			cfg: oldCfg,
+		}
+	)
+	oldCfg.rdns = newReverseDNS(res, oldCfg.ReverseDNSRate, 0, 0)
+
+	// The new configuration loaded without Resolver and with different
+	// reverse DNS settings.
+	var newCfg = &Config{
+		ReverseDNS:     true,
+		ReverseDNSRate: 20,
+	}
+	newCfg.rdns = newReverseDNS(nil, newCfg.ReverseDNSRate, 0, 0)
+
+	var err = h.Reload(newCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(h.cfg.rdns.close)
+
+	test.Assert(t, `Resolver`, Resolver(res), h.cfg.Resolver)
+	test.Assert(t, `rdns.resolver`, Resolver(res), h.cfg.rdns.resolver)
+}
+
+func TestHaminer_Reload_stopped(t *testing.T) {
+	var h = &Haminer{
+		cfg:     &Config{},
+		reloadq: make(chan *reload),
+		stopq:   make(chan struct{}),
+	}
+
+	// The Stop is called after the produce exited, but before
+	// isRunning is checked by Reload.
+	h.isRunning = true
+	close(h.stopq)
+
+	var err = h.Reload(&Config{})
+	test.Assert(t, `error`, `Reload: haminer has been stopped`, err.Error())
+}
@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defReplayBatchSize the default number of logs forwarded at once
+	// during replay.
+	defReplayBatchSize = 1000
+
+	// maxReplayLineSize the maximum size of line in the log file.
+	maxReplayLineSize = 1 << 20
+
+	// replayStdin the file name to read the logs from standard input.
+	replayStdin = `-`
+)
+
+// ReplayOptions define the options for [Replay].
+type ReplayOptions struct {
+	// Stdin the reader for file "-".
+	// Default to [os.Stdin].
+	Stdin io.Reader
+
+	// Out the writer to print the progress.
+	// Default to [io.Discard].
+	Out io.Writer
+
+	// StateFile the path to file that store the offset of the last
+	// forwarded line.
+	// If the file exist, the replay resume from the offset in the file,
+	// unless the Offset is set.
+	// The file is updated after each batch forwarded by all forwarders.
+	// If any forwarder failed, the replay stopped without updating the
+	// file, so the batch is forwarded again on resume.
+	StateFile string
+
+	// Files list of HAProxy log files to be replayed, in order.
+	// The file with suffix ".gz" is decompressed using gzip.
+	// The file "-" read the logs from Stdin.
+	Files []string
+
+	// Offset the number of lines, from all Files, to be skipped.
+	Offset int64
+
+	// Rate the maximum number of logs forwarded per second.
+	// Zero means unlimited.
+	Rate int
+
+	// BatchSize the number of logs forwarded at once.
+	// Default to 1000.
+	BatchSize int
+}
+
+// replayer contains the state of replay.
+type replayer struct {
+	h     *Haminer
+	opts  ReplayOptions
+	start time.Time
+
+	halogs []*HTTPLog
+
+	// offset the number of lines read from all files.
+	offset int64
+
+	// forwarded the number of logs forwarded since start.
+	forwarded int64
+}
+
+// Replay read the HAProxy logs from files and forward them to all
+// forwarders in the cfg.
+//
+// Each line is parsed, filtered, and pre-processed the same as the logs
+// received from HAProxy.
+func Replay(cfg *Config, opts ReplayOptions) (err error) {
+	var (
+		logp = `Replay`
+		h    = &Haminer{
+			cfg: cfg,
+		}
+	)
+
+	err = h.createForwarder()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	// Closing the forwarders flush the buffered logs and stop the
+	// partition maintainer.
+	defer h.closeForwarders()
+
+	if len(h.ff) == 0 {
+		return fmt.Errorf(`%s: no forwarder configured`, logp)
+	}
+
+	var rep *replayer
+
+	rep, err = newReplayer(h, opts)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+
+	err = rep.run()
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, logp, err)
+	}
+	return nil
+}
+
+func newReplayer(h *Haminer, opts ReplayOptions) (rep *replayer, err error) {
+	if len(opts.Files) == 0 {
+		return nil, errors.New(`no files to replay`)
+	}
+	if opts.Stdin == nil {
+		opts.Stdin = os.Stdin
+	}
+	if opts.Out == nil {
+		opts.Out = io.Discard
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defReplayBatchSize
+	}
+	if opts.Rate > 0 && opts.BatchSize > opts.Rate {
+		opts.BatchSize = opts.Rate
+	}
+
+	if opts.Offset == 0 && len(opts.StateFile) != 0 {
+		opts.Offset, err = readReplayState(opts.StateFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rep = &replayer{
+		h:      h,
+		opts:   opts,
+		halogs: make([]*HTTPLog, 0, opts.BatchSize),
+	}
+	return rep, nil
+}
+
+func (rep *replayer) run() (err error) {
+	if rep.opts.Offset > 0 {
+		fmt.Fprintf(rep.opts.Out, "replay: resume from offset %d\n",
+			rep.opts.Offset)
+	}
+
+	rep.start = time.Now()
+
+	var file string
+	for _, file = range rep.opts.Files {
+		err = rep.replayFile(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rep.flush()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(rep.opts.Out, "replay: %d logs forwarded, offset %d\n",
+		rep.forwarded, rep.offset)
+
+	return nil
+}
+
+func (rep *replayer) replayFile(file string) (err error) {
+	var r io.Reader
+
+	if file == replayStdin {
+		r = rep.opts.Stdin
+	} else {
+		var f *os.File
+
+		f, err = os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		r = f
+
+		if strings.HasSuffix(file, `.gz`) {
+			var gz *gzip.Reader
+
+			gz, err = gzip.NewReader(f)
+			if err != nil {
+				return fmt.Errorf(`%s: %w`, file, err)
+			}
+			defer gz.Close()
+
+			r = gz
+		}
+	}
+
+	err = rep.replay(r)
+	if err != nil {
+		return fmt.Errorf(`%s: %w`, file, err)
+	}
+	return nil
+}
+
+// replay read each line from r and forward it in batch.
+func (rep *replayer) replay(r io.Reader) (err error) {
+	var (
+		scanner = bufio.NewScanner(r)
+
+		halog *HTTPLog
+		line  []byte
+	)
+
+	scanner.Buffer(make([]byte, 0, 4096), maxReplayLineSize)
+
+	for scanner.Scan() {
+		rep.offset++
+		if rep.offset <= rep.opts.Offset {
+			continue
+		}
+
+		// Copy the line, since Parse modify its input.
+		line = append(line[:0], scanner.Bytes()...)
+
+		halog = ParseUDPPacket(line, rep.h.cfg.RequestHeaders)
+		if halog == nil {
+			continue
+		}
+		rep.h.enrich(rep.h.cfg, halog)
+		if !rep.h.filter(halog) {
+			continue
+		}
+		rep.h.preprocess(halog)
+		if !rep.h.cfg.sampler.sample(halog) {
+			continue
+		}
+
+		rep.halogs = append(rep.halogs, halog)
+		if len(rep.halogs) < rep.opts.BatchSize {
+			continue
+		}
+
+		err = rep.flush()
+		if err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// flush forward the logs in batch, limit the rate, and store the offset
+// into state file.
+func (rep *replayer) flush() (err error) {
+	if len(rep.halogs) != 0 {
+		err = rep.forward()
+		if err != nil {
+			return fmt.Errorf(`offset %d: %w`, rep.offset, err)
+		}
+		rep.forwarded += int64(len(rep.halogs))
+		rep.halogs = rep.halogs[:0]
+
+		rep.throttle()
+	}
+
+	if len(rep.opts.StateFile) != 0 {
+		err = writeReplayState(rep.opts.StateFile, rep.offset)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(rep.opts.Out, "replay: offset %d\n", rep.offset)
+
+	return nil
+}
+
+// forward the logs to all forwarders.
+// It will return an error if any forwarder failed to forward the logs.
+func (rep *replayer) forward() (err error) {
+	var (
+		cfg = rep.h.cfg
+
+		fw      Forwarder
+		checker forwarderChecker
+		name    string
+		errs    []error
+		ok      bool
+	)
+	for _, fw = range rep.h.ff {
+		name = forwarderName(fw)
+
+		checker, ok = fw.(forwarderChecker)
+		if !ok {
+			fw.Forwards(cfg.piiPolicy(name).apply(rep.halogs))
+			continue
+		}
+
+		err = checker.forward(cfg.piiPolicy(name).apply(rep.halogs))
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`%s: %w`, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// throttle sleep until the number of logs forwarded since start does not
+// exceed the rate.
+func (rep *replayer) throttle() {
+	if rep.opts.Rate <= 0 {
+		return
+	}
+
+	var (
+		expElapsed = time.Duration(rep.forwarded) * time.Second /
+			time.Duration(rep.opts.Rate)
+		elapsed = time.Since(rep.start)
+	)
+	if expElapsed > elapsed {
+		time.Sleep(expElapsed - elapsed)
+	}
+}
+
+// readReplayState read the offset from state file.
+// If the file does not exist, it will return 0 without an error.
+func readReplayState(path string) (offset int64, err error) {
+	var content []byte
+
+	content, err = os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	offset, err = strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf(`invalid state file %s: %w`, path, err)
+	}
+	return offset, nil
+}
+
+// writeReplayState store the offset into state file.
+func writeReplayState(path string, offset int64) (err error) {
+	var tmp = path + `.tmp`
+
+	err = os.WriteFile(tmp, []byte(strconv.FormatInt(offset, 10)+"\n"), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+// dummyForwarder collect the forwarded logs.
+type dummyForwarder struct {
+	batches [][]string
+}
+
+func (fw *dummyForwarder) Forwards(halogs []*HTTPLog) {
+	var batch []string
+	for _, halog := range halogs {
+		batch = append(batch, halog.BackendName+` `+halog.tagHTTPURL)
+	}
+	fw.batches = append(fw.batches, batch)
+}
+
+// failedForwarder always failed to forward the logs.
+type failedForwarder struct{}
+
+func (fw *failedForwarder) Forwards(_ []*HTTPLog) {}
+
+func (fw *failedForwarder) forward(_ []*HTTPLog) error {
+	return errors.New(`connection refused`)
+}
+
+func TestReplayer_run(t *testing.T) {
+	var (
+		dir   = t.TempDir()
+		lines = []string{
+			`Mar 17 05:08:28 localhost haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 - - ---- 1/1/2/3/4 5/6 "GET /users/1 HTTP/1.1"`,
+			`Mar 17 05:08:29 localhost haproxy[371]: invalid line`,
+			`Mar 17 05:08:30 localhost haproxy[371]: 10.0.0.2:52723 [17/Mar/2024:05:08:30.886] fe be-b/srv 1/2/3/4/5 200 149 - - ---- 1/1/2/3/4 5/6 "GET /users/2 HTTP/1.1"`,
+			`Mar 17 05:08:31 localhost haproxy[371]: 10.0.0.3:52724 [17/Mar/2024:05:08:31.886] fe be-a/srv 1/2/3/4/5 404 149 - - ---- 1/1/2/3/4 5/6 "GET /users/3 HTTP/1.1"`,
+		}
+		fileGz    = filepath.Join(dir, `haproxy.log.1.gz`)
+		stateFile = filepath.Join(dir, `replay.state`)
+	)
+
+	var (
+		bufgz bytes.Buffer
+		gzw   = gzip.NewWriter(&bufgz)
+	)
+	_, _ = gzw.Write([]byte(strings.Join(lines[:2], "\n") + "\n"))
+	_ = gzw.Close()
+
+	var err = os.WriteFile(fileGz, bufgz.Bytes(), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		cfg = &Config{
+			AcceptBackend: []string{`be-a`},
+		}
+		fw = &dummyForwarder{}
+		h  = &Haminer{
+			cfg: cfg,
+			ff:  []Forwarder{fw},
+		}
+	)
+
+	cfg.retags = append(cfg.retags, &tagPreprocessor{
+		name:  `http_url`,
+		regex: regexp.MustCompile(`/[0-9]+`),
+		repl:  `/:id`,
+	})
+
+	var (
+		opts = ReplayOptions{
+			Files:     []string{fileGz, `-`},
+			Stdin:     strings.NewReader(strings.Join(lines[2:], "\n")),
+			StateFile: stateFile,
+			BatchSize: 1,
+		}
+		rep *replayer
+	)
+
+	rep, err = newReplayer(h, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rep.run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var exp = [][]string{
+		{`be-a /users/:id`},
+		{`be-a /users/:id`},
+	}
+	test.Assert(t, `batches`, exp, fw.batches)
+
+	var offset int64
+
+	offset, err = readReplayState(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `offset`, int64(4), offset)
+
+	// Resume from the state file should forward nothing.
+	fw.batches = nil
+	opts.Stdin = strings.NewReader(strings.Join(lines[2:], "\n"))
+
+	rep, err = newReplayer(h, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `Offset`, int64(4), rep.opts.Offset)
+
+	err = rep.run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `batches after resume`, [][]string(nil), fw.batches)
+
+	// Replay from the start with failed forwarder should not write
+	// the state file.
+	h.ff = append(h.ff, &failedForwarder{})
+	opts.Stdin = strings.NewReader(strings.Join(lines[2:], "\n"))
+
+	err = os.Remove(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep, err = newReplayer(h, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rep.run()
+	test.Assert(t, `error`, fileGz+`: offset 1: *haminer.failedForwarder: connection refused`,
+		err.Error())
+
+	_, err = os.Stat(stateFile)
+	test.Assert(t, `state file not exist`, true, errors.Is(err, os.ErrNotExist))
+}
+
+func TestReplay(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		dbFile  = filepath.Join(dir, `haminer.db`)
+		logFile = filepath.Join(dir, `haproxy.log`)
+		line    = `Mar 17 05:08:28 localhost haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 - - ---- 1/1/2/3/4 5/6 "GET /users/1 HTTP/1.1"`
+	)
+
+	var err = os.WriteFile(logFile, []byte(line+"\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg = NewConfig()
+
+	cfg.Forwarders = map[string]*ConfigForwarder{
+		forwarderKindSqlite: {
+			URL: dbFile,
+		},
+	}
+
+	err = Replay(cfg, ReplayOptions{
+		Files: []string{logFile},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The WAL file is removed when the last connection to the database
+	// closed.
+	_, err = os.Stat(dbFile + `-wal`)
+	test.Assert(t, `WAL file not exist`, true, errors.Is(err, os.ErrNotExist))
+
+	var fw *forwarderSqlite
+
+	fw, err = openForwarderSqlite(ConfigForwarder{URL: dbFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.close()
+
+	var page httpLogPage
+
+	page, err = searchHTTPLog(fw.conn, driverNameSqlite, httpLogFilter{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `len`, 1, len(page.List))
+	test.Assert(t, `backend`, `be-a`, page.List[0].BackendName)
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// List of default values for reverse DNS.
+const (
+	defReverseDNSRate      = 10
+	defReverseDNSTTL       = time.Hour
+	defReverseDNSCacheSize = 10000
+	defReverseDNSTimeout   = 2 * time.Second
+)
+
+// Resolver lookup the host names of IP address.
+// The [net.Resolver] implement this interface.
+type Resolver interface {
+	LookupAddr(ctx context.Context, addr string) (names []string, err error)
+}
+
+// reverseDNS resolve the host name of client IP in the background, with
+// limited number of lookups per second, and cache the result.
+//
+// The lookup does not block, the IP that is not in the cache is queued
+// and its host name is available on the next lookup after its resolved.
+type reverseDNS struct {
+	resolver Resolver
+
+	cache map[string]*reverseDNSEntry
+
+	queue chan string
+	stopq chan struct{}
+
+	ttl       time.Duration
+	rate      int
+	cacheSize int
+
+	mtx sync.Mutex
+}
+
+// reverseDNSEntry the cached host name of IP address.
+type reverseDNSEntry struct {
+	expiredAt time.Time
+	host      string
+
+	// pending true if the IP is queued and not resolved yet.
+	pending bool
+}
+
+// newReverseDNS create and start the reverse DNS worker.
+// If resolver is nil, it will use [net.DefaultResolver].
+func newReverseDNS(resolver Resolver, rate, cacheSize int, ttl time.Duration) (rdns *reverseDNS) {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	if rate <= 0 {
+		rate = defReverseDNSRate
+	}
+	if cacheSize <= 0 {
+		cacheSize = defReverseDNSCacheSize
+	}
+	if ttl <= 0 {
+		ttl = defReverseDNSTTL
+	}
+
+	rdns = &reverseDNS{
+		resolver:  resolver,
+		cache:     make(map[string]*reverseDNSEntry),
+		queue:     make(chan string, rate),
+		stopq:     make(chan struct{}),
+		ttl:       ttl,
+		rate:      rate,
+		cacheSize: cacheSize,
+	}
+
+	go rdns.worker()
+
+	return rdns
+}
+
+// lookup return the cached host name of ip.
+// If the ip is not in the cache or its expired, it will be queued to be
+// resolved.
+func (rdns *reverseDNS) lookup(ip string) (host string) {
+	if rdns == nil {
+		return ``
+	}
+
+	var now = time.Now()
+
+	rdns.mtx.Lock()
+	defer rdns.mtx.Unlock()
+
+	var entry = rdns.cache[ip]
+	if entry != nil {
+		if entry.pending || now.Before(entry.expiredAt) {
+			return entry.host
+		}
+	}
+
+	select {
+	case rdns.queue <- ip:
+	default:
+		// The queue is full, the ip will be queued again on the next
+		// lookup.
+		return ``
+	}
+
+	if entry == nil {
+		if len(rdns.cache) >= rdns.cacheSize {
+			rdns.evict(now)
+		}
+		entry = &reverseDNSEntry{}
+		rdns.cache[ip] = entry
+	}
+	entry.pending = true
+
+	return entry.host
+}
+
+// evict remove the expired entries from the cache.
+// If the cache is still full, the oldest resolved entries are removed,
+// one tenth of the cache size at a time.
+// The pending entries are never removed, so the IP that is being resolved
+// is not queued again.
+// This method must be called with mtx locked.
+func (rdns *reverseDNS) evict(now time.Time) {
+	var (
+		resolved []string
+		ip       string
+		entry    *reverseDNSEntry
+	)
+	for ip, entry = range rdns.cache {
+		if entry.pending {
+			continue
+		}
+		if now.After(entry.expiredAt) {
+			delete(rdns.cache, ip)
+			continue
+		}
+		resolved = append(resolved, ip)
+	}
+	if len(rdns.cache) < rdns.cacheSize {
+		return
+	}
+
+	slices.SortFunc(resolved, func(a, b string) int {
+		return rdns.cache[a].expiredAt.Compare(rdns.cache[b].expiredAt)
+	})
+
+	var n = len(rdns.cache) - rdns.cacheSize + 1 + rdns.cacheSize/10
+	if n > len(resolved) {
+		n = len(resolved)
+	}
+	for _, ip = range resolved[:n] {
+		delete(rdns.cache, ip)
+	}
+}
+
+// worker resolve the queued IP address, at most rate per second.
+func (rdns *reverseDNS) worker() {
+	var ticker = time.NewTicker(time.Second / time.Duration(rdns.rate))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case ip := <-rdns.queue:
+			rdns.resolve(ip)
+		case <-rdns.stopq:
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-rdns.stopq:
+			return
+		}
+	}
+}
+
+// resolve the host name of ip and store it in the cache.
+// The failed lookup is cached with empty host name.
+func (rdns *reverseDNS) resolve(ip string) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), defReverseDNSTimeout)
+		names, err  = rdns.resolver.LookupAddr(ctx, ip)
+		host        string
+	)
+	cancel()
+
+	if err == nil && len(names) != 0 {
+		host = strings.TrimSuffix(names[0], `.`)
+	}
+
+	rdns.mtx.Lock()
+	var entry = rdns.cache[ip]
+	if entry == nil {
+		entry = &reverseDNSEntry{}
+		rdns.cache[ip] = entry
+	}
+	entry.host = host
+	entry.pending = false
+	entry.expiredAt = time.Now().Add(rdns.ttl)
+	rdns.mtx.Unlock()
+}
+
+// close stop the worker.
+func (rdns *reverseDNS) close() {
+	if rdns == nil {
+		return
+	}
+	close(rdns.stopq)
+}
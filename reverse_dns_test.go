@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+// testResolver resolve the IP address using static map and count the
+// number of lookups.
+type testResolver struct {
+	hosts map[string]string
+	count atomic.Int64
+}
+
+func (res *testResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	res.count.Add(1)
+	var host, ok = res.hosts[addr]
+	if !ok {
+		return nil, errors.New(`not found`)
+	}
+	return []string{host + `.`}, nil
+}
+
+func TestReverseDNS_lookup(t *testing.T) {
+	var (
+		res = &testResolver{
+			hosts: map[string]string{
+				`10.0.0.1`: `web-1.example.com`,
+			},
+		}
+		rdns = newReverseDNS(res, 100, 0, time.Hour)
+	)
+	t.Cleanup(rdns.close)
+
+	// The first lookup is queued.
+	test.Assert(t, `first lookup`, ``, rdns.lookup(`10.0.0.1`))
+	test.Assert(t, `not found`, ``, rdns.lookup(`10.0.0.2`))
+
+	var waitHost = func(ip string) (host string) {
+		var deadline = time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			rdns.mtx.Lock()
+			var entry = rdns.cache[ip]
+			var isResolved = entry != nil && !entry.pending
+			rdns.mtx.Unlock()
+			if isResolved {
+				return rdns.lookup(ip)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf(`timeout waiting %s`, ip)
+		return ``
+	}
+
+	test.Assert(t, `resolved`, `web-1.example.com`, waitHost(`10.0.0.1`))
+	test.Assert(t, `failed lookup`, ``, waitHost(`10.0.0.2`))
+
+	// The result, including the failed one, is cached.
+	rdns.lookup(`10.0.0.1`)
+	rdns.lookup(`10.0.0.2`)
+	test.Assert(t, `number of lookups`, int64(2), res.count.Load())
+}
+
+func TestReverseDNS_evict(t *testing.T) {
+	var (
+		now  = time.Now()
+		rdns = &reverseDNS{
+			cache:     make(map[string]*reverseDNSEntry),
+			cacheSize: 10,
+		}
+		x int
+	)
+
+	// The pending entry is the oldest one.
+	rdns.cache[`10.0.0.0`] = &reverseDNSEntry{pending: true}
+	for x = 1; x < rdns.cacheSize; x++ {
+		rdns.cache[fmt.Sprintf(`10.0.0.%d`, x)] = &reverseDNSEntry{
+			expiredAt: now.Add(time.Duration(x) * time.Minute),
+		}
+	}
+
+	rdns.evict(now)
+
+	var got []string
+	for ip := range rdns.cache {
+		got = append(got, ip)
+	}
+	slices.Sort(got)
+
+	var exp = []string{
+		`10.0.0.0`,
+		`10.0.0.3`,
+		`10.0.0.4`,
+		`10.0.0.5`,
+		`10.0.0.6`,
+		`10.0.0.7`,
+		`10.0.0.8`,
+		`10.0.0.9`,
+	}
+	test.Assert(t, `evict oldest`, exp, got)
+
+	// The expired entries are removed first.
+	rdns.cache[`10.0.0.1`] = &reverseDNSEntry{expiredAt: now.Add(-time.Minute)}
+	rdns.cache[`10.0.0.2`] = &reverseDNSEntry{expiredAt: now.Add(-time.Minute)}
+
+	rdns.evict(now)
+
+	test.Assert(t, `evict expired`, 8, len(rdns.cache))
+	test.Assert(t, `pending`, true, rdns.cache[`10.0.0.0`].pending)
+}
+
+func TestHTTPLog_writeIlp_clientHost(t *testing.T) {
+	var (
+		packet = []byte(`<134>Mar 17 05:08:28 haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 - - ---- 1/1/2/3/4 5/6 "GET / HTTP/1.1"`)
+		halog  = ParseUDPPacket(packet, nil)
+	)
+
+	halog.ClientLabel = `office`
+	halog.ClientHost = `host "a",b=c d\e.example.com`
+
+	var ilp bytes.Buffer
+
+	var err = halog.writeIlp(&ilp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got = ilp.String()
+	var tags, fields, _ = strings.Cut(got, ` `)
+
+	test.Assert(t, `client_label tag`, true,
+		strings.Contains(tags, `,client_label=office`))
+	test.Assert(t, `client_host not tag`, false,
+		strings.Contains(tags, `client_host`))
+	test.Assert(t, `client_host field`, true,
+		strings.Contains(fields, `,client_host="host \"a\",b=c d\\e.example.com"`))
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// termStateNormal the termination state of request that completed
+// normally.
+const termStateNormal = `----`
+
+// defSampleKeep the default list of expressions to match the logs that
+// are always forwarded: the error log, the log with status code 500 or
+// above, and the log that does not terminated normally.
+var defSampleKeep = []string{
+	`is_error || status_code >= 500 || termination_state != '` + termStateNormal + `'`,
+}
+
+// ConfigSample define the rule to forward only the sample of logs that
+// match the backend and URL.
+type ConfigSample struct {
+	// URL the regular expression to match the HTTP URL path.
+	// If its empty, the rule match all URLs.
+	URL string `ini:"::url"`
+
+	// Backend list of backend name to be matched.
+	// If its empty, the rule match all backends.
+	Backend []string `ini:"::backend"`
+
+	// Rate the probability, between 0 and 1, of matched log being
+	// forwarded.
+	Rate float64 `ini:"::rate"`
+
+	// Every forward one of every N matched logs.
+	Every int64 `ini:"::every"`
+}
+
+// sampleRule the parsed ConfigSample.
+type sampleRule struct {
+	backends map[string]struct{}
+	reURL    *regexp.Regexp
+
+	name string
+
+	rate  float64
+	every int64
+
+	// count the number of logs matched by the rule.
+	count int64
+}
+
+// sampler forward only the sample of logs, using the first rule that
+// match, ordered by its name.
+//
+// The log that match one of the keep expressions is always forwarded.
+type sampler struct {
+	rules []*sampleRule
+	keeps []filterExpr
+}
+
+// newSampler create new sampler from list of named ConfigSample and the
+// list of expressions to match the logs that are always forwarded.
+// If keep is empty, it will default to defSampleKeep.
+// It will return nil if cfgs is empty.
+func newSampler(cfgs map[string]*ConfigSample, keep []string) (smp *sampler, err error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+
+	smp = &sampler{}
+
+	if len(keep) == 0 {
+		keep = defSampleKeep
+	}
+	smp.keeps, err = compileFilterExprs(keep)
+	if err != nil {
+		return nil, fmt.Errorf(`keep: %w`, err)
+	}
+
+	var (
+		cfg  *ConfigSample
+		rule *sampleRule
+		name string
+	)
+	for name, cfg = range cfgs {
+		rule, err = newSampleRule(name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %w`, name, err)
+		}
+		smp.rules = append(smp.rules, rule)
+	}
+
+	slices.SortFunc(smp.rules, func(a, b *sampleRule) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return smp, nil
+}
+
+func newSampleRule(name string, cfg *ConfigSample) (rule *sampleRule, err error) {
+	rule = &sampleRule{
+		name:  name,
+		rate:  cfg.Rate,
+		every: cfg.Every,
+	}
+
+	if (rule.rate == 0) == (rule.every == 0) {
+		return nil, errors.New(`either rate or every must be set`)
+	}
+	if rule.rate < 0 || rule.rate > 1 {
+		return nil, fmt.Errorf(`invalid rate %v`, cfg.Rate)
+	}
+	if rule.every < 0 {
+		return nil, fmt.Errorf(`invalid every %d`, cfg.Every)
+	}
+
+	if len(cfg.URL) != 0 {
+		rule.reURL, err = regexp.Compile(cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid url: %w`, err)
+		}
+	}
+
+	for _, be := range cfg.Backend {
+		be = strings.TrimSpace(be)
+		if len(be) == 0 {
+			continue
+		}
+		if rule.backends == nil {
+			rule.backends = make(map[string]struct{})
+		}
+		rule.backends[be] = struct{}{}
+	}
+	return rule, nil
+}
+
+// isMatch return true if the backend and URL of halog match the rule.
+func (rule *sampleRule) isMatch(halog *HTTPLog) bool {
+	if rule.backends != nil {
+		var _, ok = rule.backends[halog.BackendName]
+		if !ok {
+			return false
+		}
+	}
+	if rule.reURL != nil && !rule.reURL.MatchString(halog.HTTPURL) {
+		return false
+	}
+	return true
+}
+
+// keep return true if the matched log should be forwarded.
+func (rule *sampleRule) keep() bool {
+	if rule.every != 0 {
+		var ok = rule.count%rule.every == 0
+		rule.count++
+		return ok
+	}
+	return rand.Float64() < rule.rate
+}
+
+// weight return the number of logs represented by one forwarded log.
+func (rule *sampleRule) weight() float64 {
+	if rule.every != 0 {
+		return float64(rule.every)
+	}
+	return 1 / rule.rate
+}
+
+// sample return true if halog should be forwarded, and set its
+// SampleWeight based on the matched rule.
+func (smp *sampler) sample(halog *HTTPLog) bool {
+	if smp == nil {
+		return true
+	}
+	for _, expr := range smp.keeps {
+		if expr.eval(halog) {
+			return true
+		}
+	}
+	for _, rule := range smp.rules {
+		if !rule.isMatch(halog) {
+			continue
+		}
+		if !rule.keep() {
+			return false
+		}
+		halog.SampleWeight = rule.weight()
+		return true
+	}
+	return true
+}
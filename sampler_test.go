@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestSampler_sample(t *testing.T) {
+	var (
+		cfgPath = filepath.Join(t.TempDir(), `haminer.conf`)
+		content = `[sample "health"]
+url = ^/health
+every = 10
+
+[sample "static"]
+backend = be-static
+rate = 0.5
+`
+	)
+
+	var err = os.WriteFile(cfgPath, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg = NewConfig()
+
+	err = cfg.Load(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var newLog = func(backend, url string, status int32, termState string) *HTTPLog {
+		return &HTTPLog{
+			BackendName:      backend,
+			HTTPURL:          url,
+			StatusCode:       status,
+			TerminationState: termState,
+			SampleWeight:     1,
+		}
+	}
+
+	var (
+		halog *HTTPLog
+		kept  int
+		x     int
+	)
+
+	// One of every 10 health checks is forwarded.
+	for x = range 30 {
+		halog = newLog(`be-api`, `/health`, 200, termStateNormal)
+		if cfg.sampler.sample(halog) {
+			kept++
+			test.Assert(t, `health SampleWeight`, float64(10), halog.SampleWeight)
+			test.Assert(t, `health index`, 0, x%10)
+		}
+	}
+	test.Assert(t, `health kept`, 3, kept)
+
+	// Around half of static assets are forwarded.
+	kept = 0
+	for range 1000 {
+		halog = newLog(`be-static`, `/app.js`, 200, termStateNormal)
+		if cfg.sampler.sample(halog) {
+			kept++
+			test.Assert(t, `static SampleWeight`, float64(2), halog.SampleWeight)
+		}
+	}
+	if kept < 400 || kept > 600 {
+		t.Fatalf(`static kept: got %d, want around 500`, kept)
+	}
+
+	// The errors are always forwarded.
+	for range 10 {
+		halog = newLog(`be-api`, `/health`, 503, termStateNormal)
+		test.Assert(t, `status 503`, true, cfg.sampler.sample(halog))
+		test.Assert(t, `status 503 SampleWeight`, float64(1), halog.SampleWeight)
+
+		halog = newLog(`be-static`, `/app.js`, 200, `CD--`)
+		test.Assert(t, `term state CD--`, true, cfg.sampler.sample(halog))
+	}
+
+	// The log that does not match any rule is forwarded.
+	halog = newLog(`be-api`, `/users`, 200, termStateNormal)
+	test.Assert(t, `not match`, true, cfg.sampler.sample(halog))
+	test.Assert(t, `not match SampleWeight`, float64(1), halog.SampleWeight)
+}
+
+func TestSampler_sample_keep(t *testing.T) {
+	var (
+		cfgPath = filepath.Join(t.TempDir(), `haminer.conf`)
+		content = `[haminer]
+sample_keep = status_code >= 400 && http_method != 'OPTIONS'
+sample_keep = header.host == 'admin.example.com'
+
+[sample "all"]
+every = 1000
+`
+	)
+
+	var err = os.WriteFile(cfgPath, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg = NewConfig()
+
+	err = cfg.Load(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		halog *HTTPLog
+		desc  string
+		exp   bool
+	}
+
+	var cases = []testCase{{
+		desc: `First log sampled`,
+		halog: &HTTPLog{
+			HTTPMethod:       `GET`,
+			StatusCode:       200,
+			TerminationState: termStateNormal,
+		},
+		exp: true,
+	}, {
+		desc: `Status 404`,
+		halog: &HTTPLog{
+			HTTPMethod:       `GET`,
+			StatusCode:       404,
+			TerminationState: termStateNormal,
+		},
+		exp: true,
+	}, {
+		desc: `Status 404 with method OPTIONS`,
+		halog: &HTTPLog{
+			HTTPMethod:       `OPTIONS`,
+			StatusCode:       404,
+			TerminationState: termStateNormal,
+		},
+	}, {
+		desc: `Termination state not in keep`,
+		halog: &HTTPLog{
+			HTTPMethod:       `GET`,
+			StatusCode:       200,
+			TerminationState: `CD--`,
+		},
+	}, {
+		desc: `Match header`,
+		halog: &HTTPLog{
+			HeaderRequest: map[string]string{
+				`host`: `admin.example.com`,
+			},
+			HTTPMethod:       `GET`,
+			StatusCode:       200,
+			TerminationState: termStateNormal,
+		},
+		exp: true,
+	}}
+
+	var c testCase
+	for _, c = range cases {
+		test.Assert(t, c.desc, c.exp, cfg.sampler.sample(c.halog))
+	}
+
+	_, err = newSampler(map[string]*ConfigSample{`all`: {Every: 10}},
+		[]string{`status_code >=`})
+	test.Assert(t, `invalid keep`, true, err != nil)
+}
+
+func TestNewSampler(t *testing.T) {
+	var cases = []struct {
+		cfg    ConfigSample
+		expErr string
+	}{{
+		cfg:    ConfigSample{},
+		expErr: `test: either rate or every must be set`,
+	}, {
+		cfg:    ConfigSample{Rate: 0.1, Every: 10},
+		expErr: `test: either rate or every must be set`,
+	}, {
+		cfg:    ConfigSample{Rate: 2},
+		expErr: `test: invalid rate 2`,
+	}, {
+		cfg:    ConfigSample{Every: 10, URL: `(`},
+		expErr: "test: invalid url: error parsing regexp: missing closing ): `(`",
+	}}
+
+	for _, c := range cases {
+		var _, err = newSampler(map[string]*ConfigSample{`test`: &c.cfg}, nil)
+		test.Assert(t, c.expErr, c.expErr, err.Error())
+	}
+}
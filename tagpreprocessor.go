@@ -9,6 +9,20 @@ import (
 	"strings"
 )
 
+// List of tag names that can be pre-processed.
+const (
+	tagNameHTTPURL   = `http_url`
+	tagNameHTTPQuery = `http_query`
+	tagNameClientIP  = `client_ip`
+	tagNameServer    = `server`
+	tagNameBackend   = `backend`
+	tagNameHeader    = `header`
+
+	// tagNameHeaderPrefix the prefix of tag name for request header,
+	// followed by the header name.
+	tagNameHeaderPrefix = `header:`
+)
+
 type tagPreprocessor struct {
 	name  string
 	regex *regexp.Regexp
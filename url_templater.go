@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"strings"
+)
+
+const (
+	// defURLMaxTemplates the default maximum number of URL templates
+	// learned for each backend.
+	defURLMaxTemplates = 500
+
+	// defURLMinCount the default number of times the URL template must
+	// be seen before its learned.
+	defURLMinCount = 3
+
+	// urlTemplateOther the URL template for path that has not been
+	// learned.
+	urlTemplateOther = `/other`
+)
+
+// List of placeholder that replace the path segment.
+const (
+	urlPlaceholderID    = `:id`
+	urlPlaceholderUUID  = `:uuid`
+	urlPlaceholderHex   = `:hex`
+	urlPlaceholderToken = `:token`
+	urlPlaceholderEmail = `:email`
+)
+
+// urlTemplater convert the URL path into template, by replacing the
+// path segment that looks like an identifier with placeholder, and
+// learn the frequent templates for each backend.
+//
+// The template is learned after its seen minCount times.
+// Once the backend has maxTemplates templates, or while the template has
+// not been learned, the path is replaced with "/other".
+type urlTemplater struct {
+	backends map[string]*urlTemplates
+
+	maxTemplates int
+	minCount     int
+}
+
+// urlTemplates contains the learned and candidate templates of backend.
+type urlTemplates struct {
+	learned map[string]struct{}
+
+	// candidates contains the number of times each template has been
+	// seen before its learned.
+	candidates map[string]int
+}
+
+func newURLTemplater(maxTemplates, minCount int) (urlt *urlTemplater) {
+	if maxTemplates <= 0 {
+		maxTemplates = defURLMaxTemplates
+	}
+	if minCount <= 0 {
+		minCount = defURLMinCount
+	}
+	urlt = &urlTemplater{
+		backends:     make(map[string]*urlTemplates),
+		maxTemplates: maxTemplates,
+		minCount:     minCount,
+	}
+	return urlt
+}
+
+// template return the template of path for the backend.
+func (urlt *urlTemplater) template(backend, path string) string {
+	var (
+		tpl  = normalizeURLPath(path)
+		tpls = urlt.backends[backend]
+		ok   bool
+	)
+
+	if tpls == nil {
+		tpls = &urlTemplates{
+			learned:    make(map[string]struct{}),
+			candidates: make(map[string]int),
+		}
+		urlt.backends[backend] = tpls
+	}
+
+	_, ok = tpls.learned[tpl]
+	if ok {
+		return tpl
+	}
+	if len(tpls.learned) >= urlt.maxTemplates {
+		return urlTemplateOther
+	}
+
+	var count = tpls.candidates[tpl] + 1
+	if count >= urlt.minCount {
+		delete(tpls.candidates, tpl)
+		tpls.learned[tpl] = struct{}{}
+		return tpl
+	}
+
+	// Reset the candidates to limit the memory usage when the path
+	// contains segments that are not replaced by placeholder.
+	if len(tpls.candidates) >= 10*urlt.maxTemplates {
+		clear(tpls.candidates)
+	}
+	tpls.candidates[tpl] = count
+
+	return urlTemplateOther
+}
+
+// normalizeURLPath replace each segment in the path that looks like an
+// identifier with placeholder.
+func normalizeURLPath(path string) string {
+	var (
+		segments = strings.Split(path, `/`)
+
+		seg string
+		x   int
+	)
+	for x, seg = range segments {
+		if len(seg) == 0 {
+			continue
+		}
+		segments[x] = normalizeURLSegment(seg)
+	}
+	return strings.Join(segments, `/`)
+}
+
+func normalizeURLSegment(seg string) string {
+	switch {
+	case isUUID(seg):
+		return urlPlaceholderUUID
+	case isDigits(seg):
+		return urlPlaceholderID
+	case isEmail(seg):
+		return urlPlaceholderEmail
+	case isHexHash(seg):
+		return urlPlaceholderHex
+	case isBase64Token(seg):
+		return urlPlaceholderToken
+	}
+	return seg
+}
+
+func isDigits(seg string) bool {
+	for _, c := range []byte(seg) {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') ||
+		(c >= 'A' && c <= 'F')
+}
+
+// isUUID return true if seg is in the UUID format,
+// "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx".
+func isUUID(seg string) bool {
+	if len(seg) != 36 {
+		return false
+	}
+	for x, c := range []byte(seg) {
+		switch x {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isHex(c) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isEmail return true if seg contains "@", or its encoded form "%40",
+// followed by domain.
+func isEmail(seg string) bool {
+	var x = strings.IndexByte(seg, '@')
+	if x > 0 {
+		seg = seg[x+1:]
+	} else {
+		x = strings.Index(seg, `%40`)
+		if x <= 0 {
+			return false
+		}
+		seg = seg[x+3:]
+	}
+	x = strings.IndexByte(seg, '.')
+	return x > 0 && x < len(seg)-1
+}
+
+// isHexHash return true if seg contains only hex characters with minimum
+// length of 16, for example MD5 or SHA hash.
+func isHexHash(seg string) bool {
+	if len(seg) < 16 {
+		return false
+	}
+	for _, c := range []byte(seg) {
+		if !isHex(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isBase64Token return true if seg looks like random token encoded in
+// base64: minimum length of 20 characters from base64 or base64url
+// alphabet that contains digit, lower case, and upper case letter.
+func isBase64Token(seg string) bool {
+	if len(seg) < 20 {
+		return false
+	}
+	var hasDigit, hasLower, hasUpper bool
+	for _, c := range []byte(seg) {
+		switch {
+		case c >= '0' && c <= '9':
+			hasDigit = true
+		case c >= 'a' && c <= 'z':
+			hasLower = true
+		case c >= 'A' && c <= 'Z':
+			hasUpper = true
+		case c == '-' || c == '_' || c == '+' || c == '=':
+		default:
+			return false
+		}
+	}
+	return hasDigit && hasLower && hasUpper
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestNormalizeURLPath(t *testing.T) {
+	var cases = []struct {
+		in  string
+		exp string
+	}{{
+		in:  `/`,
+		exp: `/`,
+	}, {
+		in:  `/users/1000/posts/20`,
+		exp: `/users/:id/posts/:id`,
+	}, {
+		in:  `/orders/9845a0b4-f4c3-4600-af13-45b5b0e61630`,
+		exp: `/orders/:uuid`,
+	}, {
+		in:  `/blobs/d41d8cd98f00b204e9800998ecf8427e`,
+		exp: `/blobs/:hex`,
+	}, {
+		in:  `/reset/eyJhbGciOiJIUzI1NiJ9_dGVzdA-Xy`,
+		exp: `/reset/:token`,
+	}, {
+		in:  `/users/john.doe@example.com/profile`,
+		exp: `/users/:email/profile`,
+	}, {
+		in:  `/users/john%40example.com`,
+		exp: `/users/:email`,
+	}, {
+		in:  `/static/bootstrap.bundle.min.js`,
+		exp: `/static/bootstrap.bundle.min.js`,
+	}, {
+		in:  `/api/v2/face/feed`,
+		exp: `/api/v2/face/feed`,
+	}}
+
+	for _, c := range cases {
+		test.Assert(t, c.in, c.exp, normalizeURLPath(c.in))
+	}
+}
+
+func TestURLTemplater_template(t *testing.T) {
+	var (
+		urlt = newURLTemplater(2, 2)
+		got  []string
+	)
+
+	for _, path := range []string{
+		`/users/1`,
+		`/users/2`,
+		`/posts/1`,
+		`/posts/2`,
+		`/tags/1`,
+		`/tags/2`,
+	} {
+		got = append(got, urlt.template(`be-a`, path))
+	}
+
+	var exp = []string{
+		urlTemplateOther,
+		`/users/:id`,
+		urlTemplateOther,
+		`/posts/:id`,
+		// The be-a already has two templates learned.
+		urlTemplateOther,
+		urlTemplateOther,
+	}
+	test.Assert(t, `be-a`, exp, got)
+
+	// The templates are learned per backend.
+	test.Assert(t, `be-b`, urlTemplateOther, urlt.template(`be-b`, `/users/3`))
+	test.Assert(t, `be-b`, `/users/:id`, urlt.template(`be-b`, `/users/4`))
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"strings"
+)
+
+// headerNameUserAgent the name of captured request header that contains
+// the User-Agent.
+const headerNameUserAgent = `user_agent`
+
+// uaOther the browser or OS name for user agent that is not known.
+const uaOther = `Other`
+
+// List of device type of user agent.
+const (
+	uaDeviceDesktop = `desktop`
+	uaDeviceMobile  = `mobile`
+	uaDeviceTablet  = `tablet`
+	uaDeviceBot     = `bot`
+	uaDeviceOther   = `other`
+)
+
+// uaRule map the lower case token in User-Agent into name.
+type uaRule struct {
+	token string
+	name  string
+}
+
+// uaBots list of known bots, crawlers, and HTTP clients.
+// The first rule that match is used.
+var uaBots = []uaRule{
+	{`googlebot`, `Googlebot`},
+	{`bingbot`, `Bingbot`},
+	{`yandexbot`, `YandexBot`},
+	{`baiduspider`, `Baiduspider`},
+	{`duckduckbot`, `DuckDuckBot`},
+	{`applebot`, `Applebot`},
+	{`ahrefsbot`, `AhrefsBot`},
+	{`semrushbot`, `SemrushBot`},
+	{`gptbot`, `GPTBot`},
+	{`claudebot`, `ClaudeBot`},
+	{`facebookexternalhit`, `Facebook`},
+	{`headlesschrome`, `HeadlessChrome`},
+	{`curl/`, `curl`},
+	{`wget/`, `Wget`},
+	{`python-requests`, `Python`},
+	{`python-urllib`, `Python`},
+	{`go-http-client`, `Go`},
+	{`okhttp`, `OkHttp`},
+	{`java/`, `Java`},
+	{`bot`, uaOther},
+	{`crawl`, uaOther},
+	{`spider`, uaOther},
+	{`slurp`, uaOther},
+}
+
+// uaBrowsers list of known browsers.
+// The order is important, since most of browsers also contains the token
+// of other browser, for example Edge contains "Chrome" and "Safari".
+var uaBrowsers = []uaRule{
+	{`edg/`, `Edge`},
+	{`edga/`, `Edge`},
+	{`edgios/`, `Edge`},
+	{`edge/`, `Edge`},
+	{`opr/`, `Opera`},
+	{`opera`, `Opera`},
+	{`samsungbrowser/`, `Samsung Internet`},
+	{`yabrowser/`, `Yandex`},
+	{`firefox/`, `Firefox`},
+	{`fxios/`, `Firefox`},
+	{`crios/`, `Chrome`},
+	{`chrome/`, `Chrome`},
+	{`chromium/`, `Chrome`},
+	{`safari/`, `Safari`},
+	{`msie `, `IE`},
+	{`trident/`, `IE`},
+}
+
+// uaOSes list of known operating systems.
+var uaOSes = []uaRule{
+	{`windows phone`, `Windows Phone`},
+	{`windows`, `Windows`},
+	{`iphone`, `iOS`},
+	{`ipad`, `iOS`},
+	{`ipod`, `iOS`},
+	{`android`, `Android`},
+	{`cros `, `Chrome OS`},
+	{`mac os x`, `macOS`},
+	{`macintosh`, `macOS`},
+	{`linux`, `Linux`},
+}
+
+// userAgent contains the low cardinality values parsed from User-Agent.
+type userAgent struct {
+	browser string
+	os      string
+	device  string
+	isBot   bool
+}
+
+// parseUserAgent parse the User-Agent into browser and OS name without
+// version, device type, and bot flag.
+func parseUserAgent(raw string) (ua userAgent) {
+	var lower = strings.ToLower(raw)
+
+	ua.browser, ua.isBot = matchUARule(lower, uaBots)
+	if !ua.isBot {
+		ua.browser, _ = matchUARule(lower, uaBrowsers)
+	}
+
+	var ok bool
+
+	ua.os, ok = matchUARule(lower, uaOSes)
+
+	switch {
+	case ua.isBot:
+		ua.device = uaDeviceBot
+	case strings.Contains(lower, `ipad`) || strings.Contains(lower, `tablet`):
+		ua.device = uaDeviceTablet
+	case strings.Contains(lower, `mobi`) || strings.Contains(lower, `iphone`) ||
+		strings.Contains(lower, `ipod`) || ua.os == `Windows Phone`:
+		ua.device = uaDeviceMobile
+	case ua.os == `Android`:
+		// Android without "Mobile" token is tablet.
+		ua.device = uaDeviceTablet
+	case ok:
+		ua.device = uaDeviceDesktop
+	default:
+		ua.device = uaDeviceOther
+	}
+	return ua
+}
+
+// matchUARule return the name of first rule whose token is in ua.
+// If no rule match, it will return "Other" and false.
+func matchUARule(ua string, rules []uaRule) (name string, ok bool) {
+	for _, rule := range rules {
+		if strings.Contains(ua, rule.token) {
+			return rule.name, true
+		}
+	}
+	return uaOther, false
+}
+
+// enrichUserAgent set the UABrowser, UAOS, UADevice, and IsBot in halog
+// by parsing the captured request header "user_agent".
+func enrichUserAgent(halog *HTTPLog) {
+	var raw = halog.HeaderRequest[headerNameUserAgent]
+	if len(raw) == 0 || raw == `-` {
+		return
+	}
+
+	var ua = parseUserAgent(raw)
+
+	halog.UABrowser = ua.browser
+	halog.UAOS = ua.os
+	halog.UADevice = ua.device
+	halog.IsBot = ua.isBot
+}
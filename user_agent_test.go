@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 M. Shulhan <ms@kilabit.info>
+//
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package haminer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~shulhan/pakakeh.go/lib/test"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	var cases = []struct {
+		in  string
+		exp userAgent
+	}{{
+		in:  `Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36`,
+		exp: userAgent{browser: `Chrome`, os: `Windows`, device: uaDeviceDesktop},
+	}, {
+		in:  `Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.80`,
+		exp: userAgent{browser: `Edge`, os: `Windows`, device: uaDeviceDesktop},
+	}, {
+		in:  `Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15`,
+		exp: userAgent{browser: `Safari`, os: `macOS`, device: uaDeviceDesktop},
+	}, {
+		in:  `Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0`,
+		exp: userAgent{browser: `Firefox`, os: `Linux`, device: uaDeviceDesktop},
+	}, {
+		in:  `Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0.6367.88 Mobile/15E148 Safari/604.1`,
+		exp: userAgent{browser: `Chrome`, os: `iOS`, device: uaDeviceMobile},
+	}, {
+		in:  `Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1`,
+		exp: userAgent{browser: `Safari`, os: `iOS`, device: uaDeviceTablet},
+	}, {
+		in:  `Mozilla/5.0 (Linux; Android 14; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/24.0 Chrome/117.0.0.0 Mobile Safari/537.36`,
+		exp: userAgent{browser: `Samsung Internet`, os: `Android`, device: uaDeviceMobile},
+	}, {
+		in:  `Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36`,
+		exp: userAgent{browser: `Chrome`, os: `Android`, device: uaDeviceTablet},
+	}, {
+		in:  `Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)`,
+		exp: userAgent{browser: `Googlebot`, os: uaOther, device: uaDeviceBot, isBot: true},
+	}, {
+		in:  `curl/8.7.1`,
+		exp: userAgent{browser: `curl`, os: uaOther, device: uaDeviceBot, isBot: true},
+	}, {
+		in:  `Mozilla/5.0 (compatible; MJ12bot/v1.4.8; http://mj12bot.com/)`,
+		exp: userAgent{browser: uaOther, os: uaOther, device: uaDeviceBot, isBot: true},
+	}, {
+		in:  `SomeApp/1.0`,
+		exp: userAgent{browser: uaOther, os: uaOther, device: uaDeviceOther},
+	}}
+
+	for _, c := range cases {
+		test.Assert(t, c.in, c.exp, parseUserAgent(c.in))
+	}
+}
+
+func TestEnrichUserAgent(t *testing.T) {
+	var (
+		packet = []byte(`<134>Mar 17 05:08:28 haproxy[371]: 10.0.0.1:52722 [17/Mar/2024:05:08:28.886] fe be-a/srv 1/2/3/4/5 200 149 - - ---- 1/1/2/3/4 5/6 {example.com|Googlebot/2.1 (+http://www.google.com/bot.html)} "GET / HTTP/1.1"`)
+		halog  = ParseUDPPacket(packet, []string{`host`, headerNameUserAgent})
+	)
+
+	enrichUserAgent(halog)
+
+	test.Assert(t, `UABrowser`, `Googlebot`, halog.UABrowser)
+	test.Assert(t, `UAOS`, uaOther, halog.UAOS)
+	test.Assert(t, `UADevice`, uaDeviceBot, halog.UADevice)
+	test.Assert(t, `IsBot`, true, halog.IsBot)
+
+	var ilp bytes.Buffer
+
+	var err = halog.writeIlp(&ilp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Assert(t, `writeIlp`, true, strings.Contains(ilp.String(),
+		`,ua_browser=Googlebot,ua_os=Other,ua_device=bot,is_bot=true `))
+
+	// The log without user agent is not enriched.
+	halog = &HTTPLog{}
+	enrichUserAgent(halog)
+	test.Assert(t, `without user_agent`, HTTPLog{}, *halog)
+}